@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "fmt"
+
+//go:generate bin/gen-atomicarray -name=Int64Array -elem=Int64 -type=int64 -file=int64_array.go
+//go:generate bin/gen-atomicarray -name=Uint64Array -elem=Uint64 -type=uint64 -file=uint64_array.go
+//go:generate bin/gen-atomicarray -name=Float64Array -elem=Float64 -type=float64 -float -file=float64_array.go
+
+// ArrayOption configures an atomic array, such as an Int64Array.
+type ArrayOption interface {
+	applyArray(*arrayOptions)
+}
+
+type arrayOptions struct {
+	stride int
+}
+
+type arrayOptionFunc func(*arrayOptions)
+
+func (f arrayOptionFunc) applyArray(o *arrayOptions) { f(o) }
+
+// WithCacheLinePadding places each element of an atomic array on its own
+// cache line, so that goroutines updating neighbouring elements don't slow
+// each other down through false sharing. It multiplies the memory the
+// array uses by the number of elements that would otherwise share a line.
+func WithCacheLinePadding() ArrayOption {
+	return arrayOptionFunc(func(o *arrayOptions) {
+		o.stride = _cacheLineSize / 8
+	})
+}
+
+// arrayStride returns the distance between elements of a 64-bit atomic
+// array of length n configured with opts.
+func arrayStride(n int, opts []ArrayOption) int {
+	if n < 0 {
+		panic(fmt.Sprintf("atomic: array length must not be negative, got %d", n))
+	}
+	o := arrayOptions{stride: 1}
+	for _, opt := range opts {
+		opt.applyArray(&o)
+	}
+	return o.stride
+}
+
+func checkArrayLen(name string, want, got int) {
+	if want != got {
+		panic(fmt.Sprintf("atomic: %v of length %d used with %d values", name, want, got))
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBitfieldOverflow is returned when a value does not fit in the width of
+// a Bitfield.
+var ErrBitfieldOverflow = errors.New("atomic: bitfield overflow")
+
+// BitfieldSpec declares a named field of a BitfieldLayout.
+type BitfieldSpec struct {
+	Name  string
+	Width uint
+}
+
+// BitfieldLayout describes how named fields are packed into a 64-bit word.
+//
+//	var (
+//	  layout = atomic.NewBitfieldLayout(
+//	    atomic.BitfieldSpec{Name: "state", Width: 3},
+//	    atomic.BitfieldSpec{Name: "refs", Width: 13},
+//	    atomic.BitfieldSpec{Name: "index", Width: 48},
+//	  )
+//	  stateField = layout.Field("state")
+//	  refsField  = layout.Field("refs")
+//	)
+type BitfieldLayout struct {
+	fields []Bitfield
+	byName map[string]Bitfield
+}
+
+// NewBitfieldLayout creates a BitfieldLayout from the given fields. Fields
+// are packed starting at the least significant bit, in the order given.
+//
+// NewBitfieldLayout panics if a field has no name or no width, if two fields
+// share a name, or if the fields don't fit in 64 bits.
+func NewBitfieldLayout(specs ...BitfieldSpec) *BitfieldLayout {
+	l := &BitfieldLayout{
+		fields: make([]Bitfield, 0, len(specs)),
+		byName: make(map[string]Bitfield, len(specs)),
+	}
+
+	var shift uint
+	for _, spec := range specs {
+		if spec.Name == "" {
+			panic("atomic: bitfield must have a name")
+		}
+		if spec.Width == 0 {
+			panic(fmt.Sprintf("atomic: bitfield %q must have a width", spec.Name))
+		}
+		if _, ok := l.byName[spec.Name]; ok {
+			panic(fmt.Sprintf("atomic: duplicate bitfield %q", spec.Name))
+		}
+		if shift+spec.Width > 64 {
+			panic(fmt.Sprintf("atomic: bitfield %q does not fit in 64 bits", spec.Name))
+		}
+
+		f := Bitfield{name: spec.Name, shift: shift, width: spec.Width}
+		l.fields = append(l.fields, f)
+		l.byName[spec.Name] = f
+		shift += spec.Width
+	}
+	return l
+}
+
+// Field returns the field with the given name.
+//
+// Field panics if the layout has no such field.
+func (l *BitfieldLayout) Field(name string) Bitfield {
+	f, ok := l.byName[name]
+	if !ok {
+		panic(fmt.Sprintf("atomic: unknown bitfield %q", name))
+	}
+	return f
+}
+
+// Fields returns the fields of the layout in the order they were declared.
+func (l *BitfieldLayout) Fields() []Bitfield {
+	return append([]Bitfield(nil), l.fields...)
+}
+
+// Bitfield is a field of a BitfieldLayout. Obtain one with
+// BitfieldLayout.Field.
+type Bitfield struct {
+	name  string
+	shift uint
+	width uint
+}
+
+// Name reports the name of the field.
+func (f Bitfield) Name() string {
+	return f.name
+}
+
+// Width reports the number of bits in the field.
+func (f Bitfield) Width() uint {
+	return f.width
+}
+
+// Max reports the largest value the field can hold.
+func (f Bitfield) Max() uint64 {
+	return 1<<f.width - 1
+}
+
+// Get extracts the field from word.
+func (f Bitfield) Get(word uint64) uint64 {
+	return (word >> f.shift) & f.Max()
+}
+
+// Set returns word with the field replaced by val. It returns an error
+// wrapping ErrBitfieldOverflow if val does not fit in the field.
+func (f Bitfield) Set(word, val uint64) (uint64, error) {
+	if val > f.Max() {
+		return word, f.overflow(val)
+	}
+	return word&^(f.Max()<<f.shift) | val<<f.shift, nil
+}
+
+func (f Bitfield) overflow(val interface{}) error {
+	return fmt.Errorf("%w: %v does not fit in %d-bit field %q", ErrBitfieldOverflow, val, f.width, f.name)
+}
+
+// Bitfields is an atomic 64-bit word holding the fields of a
+// BitfieldLayout. Each field can be operated on atomically on its own, and
+// Update changes several fields in a single atomic step.
+type Bitfields struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Uint64
+}
+
+// NewBitfields creates a new Bitfields holding the given word.
+func NewBitfields(word uint64) *Bitfields {
+	b := &Bitfields{}
+	b.v.Store(word)
+	return b
+}
+
+// Load atomically loads the whole word.
+func (b *Bitfields) Load() uint64 {
+	return b.v.Load()
+}
+
+// Store atomically stores the whole word.
+func (b *Bitfields) Store(word uint64) {
+	b.v.Store(word)
+}
+
+// CompareAndSwap is an atomic compare-and-swap of the whole word.
+func (b *Bitfields) CompareAndSwap(old, new uint64) (swapped bool) {
+	return b.v.CompareAndSwap(old, new)
+}
+
+// LoadField atomically loads a single field.
+func (b *Bitfields) LoadField(f Bitfield) uint64 {
+	return f.Get(b.v.Load())
+}
+
+// StoreField atomically replaces a single field, leaving the others
+// untouched. It returns an error wrapping ErrBitfieldOverflow, without
+// changing the word, if val does not fit in the field.
+func (b *Bitfields) StoreField(f Bitfield, val uint64) error {
+	_, _, err := b.Update(func(word uint64) (uint64, error) {
+		return f.Set(word, val)
+	})
+	return err
+}
+
+// AddField atomically adds delta to a single field and returns its new
+// value. It returns an error wrapping ErrBitfieldOverflow, without changing
+// the word, if the result would overflow or underflow the field.
+func (b *Bitfields) AddField(f Bitfield, delta int64) (uint64, error) {
+	_, new, err := b.Update(func(word uint64) (uint64, error) {
+		cur := f.Get(word)
+		if delta < 0 {
+			if uint64(-delta) > cur {
+				return word, f.overflow(int64(cur) + delta)
+			}
+			return f.Set(word, cur-uint64(-delta))
+		}
+		if uint64(delta) > f.Max()-cur {
+			return word, f.overflow(cur + uint64(delta))
+		}
+		return f.Set(word, cur+uint64(delta))
+	})
+	return f.Get(new), err
+}
+
+// CompareAndSwapField atomically replaces a single field with new if it
+// currently holds old, leaving the other fields untouched. It returns an
+// error wrapping ErrBitfieldOverflow if new does not fit in the field.
+func (b *Bitfields) CompareAndSwapField(f Bitfield, old, new uint64) (swapped bool, err error) {
+	if new > f.Max() {
+		return false, f.overflow(new)
+	}
+	for {
+		word := b.v.Load()
+		if f.Get(word) != old {
+			return false, nil
+		}
+		next, _ := f.Set(word, new)
+		if b.v.CompareAndSwap(word, next) {
+			return true, nil
+		}
+	}
+}
+
+// Update atomically replaces the word with the result of fn, which may read
+// and set any number of fields. fn may be called several times if other
+// goroutines change the word concurrently, so it must not have side effects.
+//
+// If fn returns an error, the word is left unchanged and Update returns the
+// error along with the word fn was given.
+func (b *Bitfields) Update(fn func(word uint64) (uint64, error)) (old, new uint64, err error) {
+	for {
+		old = b.v.Load()
+		new, err = fn(old)
+		if err != nil {
+			return old, old, err
+		}
+		if b.v.CompareAndSwap(old, new) {
+			return old, new, nil
+		}
+	}
+}
+
+// String encodes the wrapped word as a string.
+func (b *Bitfields) String() string {
+	return fmt.Sprintf("%#016x", b.Load())
+}
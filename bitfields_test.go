@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_testLayout = NewBitfieldLayout(
+		BitfieldSpec{Name: "state", Width: 3},
+		BitfieldSpec{Name: "refs", Width: 13},
+		BitfieldSpec{Name: "index", Width: 48},
+	)
+	_stateField = _testLayout.Field("state")
+	_refsField  = _testLayout.Field("refs")
+	_indexField = _testLayout.Field("index")
+)
+
+func TestBitfieldLayout(t *testing.T) {
+	fields := _testLayout.Fields()
+	require.Len(t, fields, 3, "Fields returned the wrong number of fields.")
+	assert.Equal(t, "state", fields[0].Name(), "Fields out of declaration order.")
+	assert.Equal(t, uint(13), _refsField.Width(), "Width didn't match.")
+	assert.Equal(t, uint64(7), _stateField.Max(), "Max didn't match.")
+	assert.Equal(t, uint64(1<<48-1), _indexField.Max(), "Max didn't match.")
+
+	word, err := _refsField.Set(0, 5)
+	require.NoError(t, err, "Set failed.")
+	assert.Equal(t, uint64(5<<3), word, "Set placed the field at the wrong offset.")
+	assert.Equal(t, uint64(5), _refsField.Get(word), "Get didn't read back the field.")
+
+	_, err = _stateField.Set(0, 8)
+	assert.True(t, errors.Is(err, ErrBitfieldOverflow), "Set should report overflow, got %v.", err)
+
+	full := NewBitfieldLayout(BitfieldSpec{Name: "all", Width: 64}).Field("all")
+	assert.Equal(t, uint64(math.MaxUint64), full.Max(), "Max of a 64-bit field didn't match.")
+
+	t.Run("panics", func(t *testing.T) {
+		tests := []struct {
+			desc  string
+			specs []BitfieldSpec
+		}{
+			{desc: "no name", specs: []BitfieldSpec{{Width: 1}}},
+			{desc: "no width", specs: []BitfieldSpec{{Name: "a"}}},
+			{desc: "duplicate", specs: []BitfieldSpec{{Name: "a", Width: 1}, {Name: "a", Width: 1}}},
+			{desc: "too wide", specs: []BitfieldSpec{{Name: "a", Width: 60}, {Name: "b", Width: 5}}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.desc, func(t *testing.T) {
+				assert.Panics(t, func() { NewBitfieldLayout(tt.specs...) })
+			})
+		}
+
+		assert.Panics(t, func() { _testLayout.Field("missing") }, "Field should panic on unknown names.")
+	})
+}
+
+func TestBitfields(t *testing.T) {
+	atom := NewBitfields(0)
+
+	require.NoError(t, atom.StoreField(_stateField, 2), "StoreField failed.")
+	require.NoError(t, atom.StoreField(_indexField, 1<<40), "StoreField failed.")
+	assert.Equal(t, uint64(2), atom.LoadField(_stateField), "LoadField didn't read the stored value.")
+	assert.Equal(t, uint64(1<<40), atom.LoadField(_indexField), "LoadField didn't read the stored value.")
+
+	refs, err := atom.AddField(_refsField, 3)
+	require.NoError(t, err, "AddField failed.")
+	assert.Equal(t, uint64(3), refs, "AddField returned the wrong value.")
+	refs, err = atom.AddField(_refsField, -1)
+	require.NoError(t, err, "AddField failed.")
+	assert.Equal(t, uint64(2), refs, "AddField returned the wrong value.")
+
+	swapped, err := atom.CompareAndSwapField(_stateField, 1, 4)
+	require.NoError(t, err, "CompareAndSwapField failed.")
+	assert.False(t, swapped, "CompareAndSwapField of the wrong value reported a swap.")
+	swapped, err = atom.CompareAndSwapField(_stateField, 2, 4)
+	require.NoError(t, err, "CompareAndSwapField failed.")
+	assert.True(t, swapped, "CompareAndSwapField didn't report a swap.")
+
+	assert.Equal(t, uint64(4), atom.LoadField(_stateField), "Fields interfered with each other.")
+	assert.Equal(t, uint64(2), atom.LoadField(_refsField), "Fields interfered with each other.")
+	assert.Equal(t, uint64(1<<40), atom.LoadField(_indexField), "Fields interfered with each other.")
+
+	t.Run("overflow", func(t *testing.T) {
+		before := atom.Load()
+
+		err := atom.StoreField(_stateField, 8)
+		assert.True(t, errors.Is(err, ErrBitfieldOverflow), "StoreField should report overflow, got %v.", err)
+
+		_, err = atom.AddField(_refsField, int64(_refsField.Max()))
+		assert.True(t, errors.Is(err, ErrBitfieldOverflow), "AddField should report overflow, got %v.", err)
+
+		_, err = atom.AddField(_refsField, -3)
+		assert.True(t, errors.Is(err, ErrBitfieldOverflow), "AddField should report underflow, got %v.", err)
+
+		_, err = atom.CompareAndSwapField(_stateField, 4, 8)
+		assert.True(t, errors.Is(err, ErrBitfieldOverflow), "CompareAndSwapField should report overflow, got %v.", err)
+
+		assert.Equal(t, before, atom.Load(), "Overflowing updates changed the word.")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		old, new, err := atom.Update(func(word uint64) (uint64, error) {
+			word, _ = _stateField.Set(word, 1)
+			return _refsField.Set(word, _refsField.Get(word)+10)
+		})
+		require.NoError(t, err, "Update failed.")
+		assert.Equal(t, uint64(4), _stateField.Get(old), "Update returned the wrong old word.")
+		assert.Equal(t, uint64(1), _stateField.Get(new), "Update returned the wrong new word.")
+		assert.Equal(t, new, atom.Load(), "Update didn't store the new word.")
+		assert.Equal(t, uint64(12), atom.LoadField(_refsField), "Update didn't change every field.")
+
+		wantErr := errors.New("great sadness")
+		_, _, err = atom.Update(func(word uint64) (uint64, error) {
+			return 0, wantErr
+		})
+		assert.Equal(t, wantErr, err, "Update didn't return the error from fn.")
+		assert.Equal(t, new, atom.Load(), "Failed Update changed the word.")
+	})
+
+	t.Run("whole word", func(t *testing.T) {
+		atom.Store(42)
+		assert.True(t, atom.CompareAndSwap(42, 43), "CompareAndSwap didn't report a swap.")
+		assert.Equal(t, "0x000000000000002b", atom.String(), "String() returned an unexpected value.")
+	})
+}
+
+func TestBitfieldsConcurrentAdd(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 1000
+	)
+
+	atom := NewBitfields(0)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_, err := atom.AddField(_indexField, 1)
+				assert.NoError(t, err)
+				_, err = atom.AddField(_refsField, 1)
+				assert.NoError(t, err)
+				_, err = atom.AddField(_refsField, -1)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(goroutines*iterations), atom.LoadField(_indexField), "Lost concurrent updates.")
+	assert.Equal(t, uint64(0), atom.LoadField(_refsField), "Lost concurrent updates.")
+	assert.Equal(t, uint64(0), atom.LoadField(_stateField), "Updates leaked into another field.")
+}
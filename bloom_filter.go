@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Version of the format produced by BloomFilter.MarshalBinary.
+const _bloomEncodingVersion = 1
+
+// BloomFilter is a probabilistic set membership test.
+//
+// Test never reports false for a key that was added, but may report true
+// for a key that wasn't. Bits are set with an atomic Or on Uint64 words, so
+// all methods are lock-free and may be called concurrently, except for
+// UnmarshalBinary.
+//
+// The zero value is not usable; create BloomFilters with NewBloomFilter.
+type BloomFilter struct {
+	_ nocmp // disallow non-atomic comparison
+
+	hashes int
+	words  []Uint64
+}
+
+// NewBloomFilter creates a BloomFilter sized to hold the expected number of
+// keys with the given false positive rate.
+//
+// NewBloomFilter panics if expectedItems is zero or if falsePositiveRate is
+// not strictly between 0 and 1.
+func NewBloomFilter(expectedItems uint64, falsePositiveRate float64) *BloomFilter {
+	if expectedItems == 0 {
+		panic("atomic: BloomFilter must expect at least one item")
+	}
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) {
+		panic(fmt.Sprintf("atomic: BloomFilter false positive rate must be in (0, 1), got %v", falsePositiveRate))
+	}
+
+	n := float64(expectedItems)
+	bits := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	words := uint64(math.Ceil(bits / 64))
+	hashes := int(math.Round(float64(words*64) / n * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+	if hashes > math.MaxUint8 {
+		hashes = math.MaxUint8
+	}
+
+	return &BloomFilter{
+		hashes: hashes,
+		words:  make([]Uint64, words),
+	}
+}
+
+// NumBits reports the number of bits in the filter.
+func (f *BloomFilter) NumBits() uint64 {
+	return uint64(len(f.words)) * 64
+}
+
+// NumHashes reports the number of bits set for each key.
+func (f *BloomFilter) NumHashes() int {
+	return f.hashes
+}
+
+// Add atomically adds key to the filter.
+func (f *BloomFilter) Add(key []byte) {
+	f.TestAndAdd(key)
+}
+
+// Test reports whether key may have been added to the filter.
+func (f *BloomFilter) Test(key []byte) bool {
+	h1, h2 := hashKey(key)
+	for i := 0; i < f.hashes; i++ {
+		word, mask := f.bit(i, h1, h2)
+		if word.Load()&mask == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAndAdd atomically adds key to the filter and reports whether it may
+// have been present beforehand.
+func (f *BloomFilter) TestAndAdd(key []byte) (present bool) {
+	h1, h2 := hashKey(key)
+	present = true
+	for i := 0; i < f.hashes; i++ {
+		word, mask := f.bit(i, h1, h2)
+		if orUint64(word, mask)&mask == 0 {
+			present = false
+		}
+	}
+	return present
+}
+
+// bit returns the word and mask of the i-th bit of a key with hashes h1 and
+// h2.
+func (f *BloomFilter) bit(i int, h1, h2 uint64) (*Uint64, uint64) {
+	idx := (h1 + uint64(i)*h2) % f.NumBits()
+	return &f.words[idx/64], 1 << (idx % 64)
+}
+
+// Union atomically adds every key in other to f. The two filters must have
+// the same number of bits and hashes.
+func (f *BloomFilter) Union(other *BloomFilter) error {
+	if len(f.words) != len(other.words) || f.hashes != other.hashes {
+		return fmt.Errorf("atomic: cannot union BloomFilter of %d bits and %d hashes into %d bits and %d hashes",
+			other.NumBits(), other.hashes, f.NumBits(), f.hashes)
+	}
+	for i := range other.words {
+		if w := other.words[i].Load(); w != 0 {
+			orUint64(&f.words[i], w)
+		}
+	}
+	return nil
+}
+
+// MarshalBinary encodes the BloomFilter into a binary form.
+//
+// The encoding is a version byte (currently 1), the number of hashes as a
+// byte, the number of 64-bit words as a little-endian uint64, and then each
+// word in little-endian order.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 10+8*len(f.words))
+	b[0] = _bloomEncodingVersion
+	b[1] = byte(f.hashes)
+	binary.LittleEndian.PutUint64(b[2:], uint64(len(f.words)))
+	for i := range f.words {
+		binary.LittleEndian.PutUint64(b[10+8*i:], f.words[i].Load())
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a BloomFilter produced by MarshalBinary, replacing
+// the receiver's contents.
+//
+// UnmarshalBinary must not be called concurrently with other methods.
+func (f *BloomFilter) UnmarshalBinary(b []byte) error {
+	if len(b) < 10 {
+		return errors.New("atomic: BloomFilter encoding is too short")
+	}
+	if b[0] != _bloomEncodingVersion {
+		return fmt.Errorf("atomic: unsupported BloomFilter encoding version %d", b[0])
+	}
+	hashes := int(b[1])
+	if hashes == 0 {
+		return errors.New("atomic: BloomFilter encoding has no hashes")
+	}
+	n := binary.LittleEndian.Uint64(b[2:])
+	if n == 0 || uint64(len(b)-10)/8 != n || (len(b)-10)%8 != 0 {
+		return fmt.Errorf("atomic: BloomFilter encoding of %d bytes doesn't hold %d words", len(b), n)
+	}
+
+	words := make([]Uint64, n)
+	for i := range words {
+		words[i].v = binary.LittleEndian.Uint64(b[10+8*i:])
+	}
+
+	f.hashes = hashes
+	f.words = words
+	return nil
+}
+
+// orUint64 atomically sets the bits of mask in u and returns the old value.
+func orUint64(u *Uint64, mask uint64) (old uint64) {
+	for {
+		old = u.Load()
+		if old&mask == mask || u.CompareAndSwap(old, old|mask) {
+			return old
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"encoding/binary"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilter(t *testing.T) {
+	const (
+		items = 10000
+		rate  = 0.01
+	)
+
+	f := NewBloomFilter(items, rate)
+	assert.Equal(t, uint64(95872), f.NumBits(), "NumBits didn't match the sizing formula.")
+	assert.Equal(t, 7, f.NumHashes(), "NumHashes didn't match the sizing formula.")
+
+	for i := 0; i < items; i++ {
+		f.Add([]byte("key-" + strconv.Itoa(i)))
+	}
+	for i := 0; i < items; i++ {
+		require.True(t, f.Test([]byte("key-"+strconv.Itoa(i))), "Added key wasn't found.")
+	}
+
+	var falsePositives int
+	for i := 0; i < items; i++ {
+		if f.Test([]byte("other-" + strconv.Itoa(i))) {
+			falsePositives++
+		}
+	}
+	assert.True(t, float64(falsePositives) < 2*rate*items,
+		"Too many false positives: %v", falsePositives)
+
+	t.Run("TestAndAdd", func(t *testing.T) {
+		f := NewBloomFilter(100, rate)
+		assert.False(t, f.TestAndAdd([]byte("foo")), "First TestAndAdd should report absent.")
+		assert.True(t, f.TestAndAdd([]byte("foo")), "Second TestAndAdd should report present.")
+	})
+}
+
+func TestBloomFilterConcurrentAdd(t *testing.T) {
+	const (
+		goroutines = 8
+		perRoutine = 1000
+	)
+
+	f := NewBloomFilter(goroutines*perRoutine, 0.01)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perRoutine; i++ {
+				f.Add([]byte(strconv.Itoa(g*perRoutine + i)))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	for i := 0; i < goroutines*perRoutine; i++ {
+		require.True(t, f.Test([]byte(strconv.Itoa(i))), "Concurrently added key %v wasn't found.", i)
+	}
+}
+
+func TestBloomFilterUnion(t *testing.T) {
+	a := NewBloomFilter(1000, 0.01)
+	b := NewBloomFilter(1000, 0.01)
+	a.Add([]byte("foo"))
+	b.Add([]byte("bar"))
+
+	require.NoError(t, a.Union(b), "Union failed.")
+	assert.True(t, a.Test([]byte("foo")), "Union lost an existing key.")
+	assert.True(t, a.Test([]byte("bar")), "Union didn't add the other filter's keys.")
+
+	assert.Error(t, a.Union(NewBloomFilter(2000, 0.01)), "Union of mismatched sizes should fail.")
+	mismatched := &BloomFilter{hashes: a.NumHashes() + 1, words: make([]Uint64, len(a.words))}
+	assert.Error(t, a.Union(mismatched), "Union of mismatched hashes should fail.")
+}
+
+func TestBloomFilterBinary(t *testing.T) {
+	f := NewBloomFilter(1000, 0.01)
+	f.Add([]byte("foo"))
+
+	b, err := f.MarshalBinary()
+	require.NoError(t, err, "MarshalBinary failed.")
+	require.Len(t, b, 10+8*int(f.NumBits()/64), "Unexpected encoding length.")
+	assert.Equal(t, byte(1), b[0], "Unexpected encoding version.")
+	assert.Equal(t, byte(f.NumHashes()), b[1], "Unexpected number of hashes.")
+	assert.Equal(t, f.NumBits()/64, binary.LittleEndian.Uint64(b[2:]), "Unexpected number of words.")
+
+	var got BloomFilter
+	require.NoError(t, got.UnmarshalBinary(b), "UnmarshalBinary failed.")
+	assert.Equal(t, f.NumBits(), got.NumBits(), "NumBits wasn't restored.")
+	assert.Equal(t, f.NumHashes(), got.NumHashes(), "NumHashes wasn't restored.")
+	assert.True(t, got.Test([]byte("foo")), "Keys weren't restored.")
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			desc string
+			give []byte
+		}{
+			{desc: "empty", give: nil},
+			{desc: "bad version", give: []byte{2, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+			{desc: "no hashes", give: []byte{1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+			{desc: "no words", give: []byte{1, 1, 0, 0, 0, 0, 0, 0, 0, 0}},
+			{desc: "truncated", give: []byte{1, 1, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+			{desc: "trailing bytes", give: []byte{1, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.desc, func(t *testing.T) {
+				var f BloomFilter
+				assert.Error(t, f.UnmarshalBinary(tt.give))
+			})
+		}
+	})
+}
+
+func TestNewBloomFilterPanics(t *testing.T) {
+	assert.Panics(t, func() { NewBloomFilter(0, 0.1) })
+	assert.Panics(t, func() { NewBloomFilter(1, 0) })
+	assert.Panics(t, func() { NewBloomFilter(1, 1) })
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"fmt"
+	"math"
+)
+
+// CountMinSketch estimates how often each key has been seen.
+//
+// The sketch is a depth x width grid of Uint64 counters. Each key maps to one
+// counter per row, and its estimate is the smallest of those counters. The
+// estimate never undercounts; with width w and depth d, it overcounts by more
+// than e/w times Total with probability at most e^-d.
+//
+// All methods are lock-free and may be called concurrently.
+//
+// The zero value is not usable; create CountMinSketches with
+// NewCountMinSketch.
+type CountMinSketch struct {
+	_ nocmp // disallow non-atomic comparison
+
+	// total comes first so that it's 8-byte aligned on 32-bit platforms.
+	total        Uint64
+	width        int
+	depth        int
+	conservative bool
+	counters     []Uint64 // depth rows of width counters each
+}
+
+// CountMinSketchOption customizes a CountMinSketch.
+type CountMinSketchOption interface {
+	applyCountMinSketch(*CountMinSketch)
+}
+
+type conservativeUpdateOption struct{}
+
+func (conservativeUpdateOption) applyCountMinSketch(s *CountMinSketch) {
+	s.conservative = true
+}
+
+// ConservativeUpdate makes Add raise only the counters that are below the
+// key's new estimate instead of incrementing all of them. This reduces
+// overcounting noticeably, but it gives up the guarantee that estimates never
+// undercount: when Adds of the same key race, the estimate may rise by only
+// the larger of the racing increments.
+func ConservativeUpdate() CountMinSketchOption {
+	return conservativeUpdateOption{}
+}
+
+// NewCountMinSketch creates a CountMinSketch with the given number of
+// counters per row and the given number of rows.
+//
+// To estimate frequencies within epsilon*Total with probability 1-delta, use
+// a width of ceil(e/epsilon) and a depth of ceil(ln(1/delta)).
+//
+// NewCountMinSketch panics if width or depth is less than one.
+func NewCountMinSketch(width, depth int, opts ...CountMinSketchOption) *CountMinSketch {
+	if width < 1 || depth < 1 {
+		panic(fmt.Sprintf("atomic: CountMinSketch dimensions must be positive, got %dx%d", width, depth))
+	}
+	s := &CountMinSketch{
+		width:    width,
+		depth:    depth,
+		counters: make([]Uint64, width*depth),
+	}
+	for _, opt := range opts {
+		opt.applyCountMinSketch(s)
+	}
+	return s
+}
+
+// Width reports the number of counters in each row.
+func (s *CountMinSketch) Width() int {
+	return s.width
+}
+
+// Depth reports the number of rows.
+func (s *CountMinSketch) Depth() int {
+	return s.depth
+}
+
+// Total reports the sum of all counts added to the sketch.
+func (s *CountMinSketch) Total() uint64 {
+	return s.total.Load()
+}
+
+// counter returns the counter for the given row of a key with hashes h1 and
+// h2.
+func (s *CountMinSketch) counter(row int, h1, h2 uint64) *Uint64 {
+	col := (h1 + uint64(row)*h2) % uint64(s.width)
+	return &s.counters[row*s.width+int(col)]
+}
+
+// Add atomically records n occurrences of key and returns the key's new
+// estimated count.
+func (s *CountMinSketch) Add(key []byte, n uint64) uint64 {
+	s.total.Add(n)
+	h1, h2 := hashKey(key)
+
+	if !s.conservative {
+		est := uint64(math.MaxUint64)
+		for row := 0; row < s.depth; row++ {
+			if v := s.counter(row, h1, h2).Add(n); v < est {
+				est = v
+			}
+		}
+		return est
+	}
+
+	est := s.estimate(h1, h2) + n
+	for row := 0; row < s.depth; row++ {
+		maxUint64(s.counter(row, h1, h2), est)
+	}
+	return est
+}
+
+// Estimate reports the estimated number of occurrences of key.
+func (s *CountMinSketch) Estimate(key []byte) uint64 {
+	h1, h2 := hashKey(key)
+	return s.estimate(h1, h2)
+}
+
+func (s *CountMinSketch) estimate(h1, h2 uint64) uint64 {
+	est := uint64(math.MaxUint64)
+	for row := 0; row < s.depth; row++ {
+		if v := s.counter(row, h1, h2).Load(); v < est {
+			est = v
+		}
+	}
+	return est
+}
+
+// Decay atomically halves every counter, so that old occurrences weigh less
+// than recent ones.
+//
+// Each counter is halved atomically, but not all of them at once: counts added
+// concurrently may or may not be halved.
+func (s *CountMinSketch) Decay() {
+	halveUint64(&s.total)
+	for i := range s.counters {
+		halveUint64(&s.counters[i])
+	}
+}
+
+// Merge atomically adds the counters of other into s, so that s estimates
+// the combined frequencies of both. The two sketches must have the same
+// dimensions.
+func (s *CountMinSketch) Merge(other *CountMinSketch) error {
+	if s.width != other.width || s.depth != other.depth {
+		return fmt.Errorf("atomic: cannot merge %dx%d CountMinSketch into %dx%d",
+			other.width, other.depth, s.width, s.depth)
+	}
+	s.total.Add(other.total.Load())
+	for i := range other.counters {
+		if v := other.counters[i].Load(); v > 0 {
+			s.counters[i].Add(v)
+		}
+	}
+	return nil
+}
+
+// maxUint64 atomically raises u to val if it is smaller.
+func maxUint64(u *Uint64, val uint64) {
+	for {
+		old := u.Load()
+		if old >= val || u.CompareAndSwap(old, val) {
+			return
+		}
+	}
+}
+
+// halveUint64 atomically halves u.
+func halveUint64(u *Uint64) {
+	for {
+		old := u.Load()
+		if old == 0 || u.CompareAndSwap(old, old/2) {
+			return
+		}
+	}
+}
+
+// hashKey derives two independent 64-bit hashes of key. Sketches combine
+// them as h1 + i*h2 to get as many hash functions as they need.
+//
+// The hashes are stable across processes and platforms so that sketches
+// can be merged and persisted.
+func hashKey(key []byte) (h1, h2 uint64) {
+	// FNV-1a, followed by a finalizer to spread short keys across all bits.
+	h := uint64(14695981039346656037)
+	for _, c := range key {
+		h ^= uint64(c)
+		h *= 1099511628211
+	}
+	h1 = splitmix64(h)
+
+	// Make the second hash odd so that it never maps every row onto the
+	// same column.
+	return h1, splitmix64(h1) | 1
+}
+
+// splitmix64 scrambles x into a well-distributed 64-bit value.
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
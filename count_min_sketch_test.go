@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zipfCounts builds a skewed stream of keys where key i occurs about
+// 10000/(i+1) times.
+func zipfCounts(keys int) map[string]uint64 {
+	counts := make(map[string]uint64, keys)
+	for i := 0; i < keys; i++ {
+		counts["key-"+strconv.Itoa(i)] = uint64(10000/(i+1)) + 1
+	}
+	return counts
+}
+
+func TestCountMinSketchErrorBound(t *testing.T) {
+	const (
+		epsilon = 0.001
+		delta   = 0.01
+	)
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	counts := zipfCounts(5000)
+
+	tests := []struct {
+		desc string
+		opts []CountMinSketchOption
+	}{
+		{desc: "standard"},
+		{desc: "conservative", opts: []CountMinSketchOption{ConservativeUpdate()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := NewCountMinSketch(width, depth, tt.opts...)
+			require.Equal(t, width, s.Width(), "Width didn't match.")
+			require.Equal(t, depth, s.Depth(), "Depth didn't match.")
+
+			var total uint64
+			for k, n := range counts {
+				s.Add([]byte(k), n)
+				total += n
+			}
+			require.Equal(t, total, s.Total(), "Total didn't match.")
+
+			bound := uint64(epsilon * float64(total))
+			var exceeded int
+			for k, n := range counts {
+				est := s.Estimate([]byte(k))
+				require.Truef(t, est >= n, "Estimate %v for %q undercounted %v.", est, k, n)
+				if est-n > bound {
+					exceeded++
+				}
+			}
+			assert.Truef(t, float64(exceeded) <= delta*float64(len(counts)),
+				"%v estimates exceeded the error bound.", exceeded)
+		})
+	}
+}
+
+func TestCountMinSketchConcurrentAdd(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 1000
+	)
+
+	s := NewCountMinSketch(100, 4)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				s.Add([]byte("hot"), 1)
+				s.Add([]byte("cold-"+strconv.Itoa(i)), 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(2*goroutines*iterations), s.Total(), "Total didn't match.")
+	assert.True(t, s.Estimate([]byte("hot")) >= goroutines*iterations,
+		"Estimate undercounted concurrent Adds.")
+}
+
+func TestCountMinSketchDecay(t *testing.T) {
+	s := NewCountMinSketch(64, 3)
+	require.Equal(t, uint64(10), s.Add([]byte("foo"), 10), "Add didn't return the estimate.")
+	s.Add([]byte("bar"), 3)
+
+	s.Decay()
+	assert.Equal(t, uint64(5), s.Estimate([]byte("foo")), "Decay didn't halve the estimate.")
+	assert.Equal(t, uint64(6), s.Total(), "Decay didn't halve the total.")
+
+	s.Decay()
+	s.Decay()
+	s.Decay()
+	assert.Equal(t, uint64(0), s.Estimate([]byte("foo")), "Repeated Decay should reach zero.")
+}
+
+func TestCountMinSketchMerge(t *testing.T) {
+	a := NewCountMinSketch(64, 3)
+	b := NewCountMinSketch(64, 3)
+	a.Add([]byte("foo"), 2)
+	b.Add([]byte("foo"), 3)
+	b.Add([]byte("bar"), 4)
+
+	require.NoError(t, a.Merge(b), "Merge failed.")
+	assert.Equal(t, uint64(5), a.Estimate([]byte("foo")), "Merge didn't combine counts.")
+	assert.Equal(t, uint64(4), a.Estimate([]byte("bar")), "Merge didn't copy counts.")
+	assert.Equal(t, uint64(9), a.Total(), "Merge didn't combine totals.")
+
+	assert.Error(t, a.Merge(NewCountMinSketch(32, 3)), "Merge of mismatched width should fail.")
+	assert.Error(t, a.Merge(NewCountMinSketch(64, 2)), "Merge of mismatched depth should fail.")
+}
+
+func TestNewCountMinSketchPanics(t *testing.T) {
+	assert.Panics(t, func() { NewCountMinSketch(0, 1) })
+	assert.Panics(t, func() { NewCountMinSketch(1, 0) })
+}
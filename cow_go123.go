@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.23
+// +build go1.23
+
+package atomic
+
+import "iter"
+
+// All returns an iterator over the indexes and items of a snapshot of the
+// slice taken when iteration starts.
+func (c *COWSlice[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		c.Range(yield)
+	}
+}
+
+// Values returns an iterator over the items of a snapshot of the slice
+// taken when iteration starts.
+func (c *COWSlice[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range c.Snapshot() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the items of a snapshot of the set taken
+// when iteration starts.
+func (c *COWSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		c.Range(yield)
+	}
+}
+
+// All returns an iterator over the keys and values of a snapshot of the map
+// taken when iteration starts.
+func (c *COWMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		c.Range(yield)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.23
+// +build go1.23
+
+package atomic
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCOWIterators(t *testing.T) {
+	s := NewCOWSlice(1, 2, 3)
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(s.Values()), "Values didn't yield every item.")
+	for i, item := range s.All() {
+		assert.Equal(t, i+1, item, "All yielded the wrong pair.")
+		if i == 1 {
+			break
+		}
+	}
+
+	set := NewCOWSet("foo", "bar")
+	assert.ElementsMatch(t, []string{"foo", "bar"}, slices.Collect(set.All()), "All didn't yield every item.")
+
+	m := NewCOWMap(map[string]int{"a": 1, "b": 2})
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, maps.Collect(m.All()), "All didn't yield every entry.")
+}
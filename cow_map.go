@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import "encoding/json"
+
+// COWMap is a copy-on-write map for read-mostly workloads.
+//
+// Readers work on an immutable snapshot of the map, so Load, Range and Len
+// are wait-free and never block writers. Every write copies the whole map
+// and swaps the copy in, so writes cost O(n); group several writes with
+// Batch to pay that cost once.
+//
+// The zero value is an empty map ready to use.
+type COWMap[K comparable, V any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	m Pointer[map[K]V]
+}
+
+// NewCOWMap creates a COWMap holding a copy of the given map.
+func NewCOWMap[K comparable, V any](m map[K]V) *COWMap[K, V] {
+	c := &COWMap[K, V]{}
+	if len(m) > 0 {
+		c.m.Store(cloneMap(&m))
+	}
+	return c
+}
+
+func cloneMap[K comparable, V any](cur *map[K]V) *map[K]V {
+	var m map[K]V
+	if cur == nil {
+		m = make(map[K]V)
+	} else {
+		m = make(map[K]V, len(*cur)+1)
+		for k, v := range *cur {
+			m[k] = v
+		}
+	}
+	return &m
+}
+
+func (c *COWMap[K, V]) snapshot() map[K]V {
+	if m := c.m.Load(); m != nil {
+		return *m
+	}
+	return nil
+}
+
+// Load returns the value stored for key, if any.
+func (c *COWMap[K, V]) Load(key K) (value V, ok bool) {
+	value, ok = c.snapshot()[key]
+	return value, ok
+}
+
+// Range calls fn for each key and value in the map until fn returns false.
+//
+// Range iterates over a single snapshot of the map: writes made during
+// iteration are not visible to it.
+func (c *COWMap[K, V]) Range(fn func(key K, value V) bool) {
+	for k, v := range c.snapshot() {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// Len reports the number of entries in the map.
+func (c *COWMap[K, V]) Len() int {
+	return len(c.snapshot())
+}
+
+// Store sets the value for key.
+func (c *COWMap[K, V]) Store(key K, value V) {
+	c.Batch(func(m map[K]V) {
+		m[key] = value
+	})
+}
+
+// Delete removes the value for key, if any.
+func (c *COWMap[K, V]) Delete(key K) {
+	c.m.Modify(func(cur *map[K]V) (*map[K]V, error) {
+		if cur == nil {
+			return cur, nil
+		}
+		if _, ok := (*cur)[key]; !ok {
+			// Nothing to delete, so don't bother copying.
+			return cur, nil
+		}
+		next := cloneMap(cur)
+		delete(*next, key)
+		return next, nil
+	})
+}
+
+// Update atomically replaces the value for key with the result of fn, which
+// is given the current value and whether one was present. The entry is
+// deleted if fn returns false for keep.
+//
+// fn may be called several times if other goroutines write to the map
+// concurrently, so it must not have side effects.
+func (c *COWMap[K, V]) Update(key K, fn func(old V, loaded bool) (new V, keep bool)) {
+	c.Batch(func(m map[K]V) {
+		old, loaded := m[key]
+		if new, keep := fn(old, loaded); keep {
+			m[key] = new
+		} else {
+			delete(m, key)
+		}
+	})
+}
+
+// Batch calls fn with a private copy of the map and atomically swaps the
+// result in, so that readers observe all of fn's changes at once.
+//
+// fn may be called several times if other goroutines write to the map
+// concurrently, so it must not have side effects, and it must not retain
+// the map after it returns.
+func (c *COWMap[K, V]) Batch(fn func(m map[K]V)) {
+	c.m.ModifyClone(cloneMap[K, V], func(next *map[K]V) error {
+		fn(*next)
+		return nil
+	})
+}
+
+// MarshalJSON encodes the current snapshot of the map into a JSON object.
+func (c *COWMap[K, V]) MarshalJSON() ([]byte, error) {
+	m := c.snapshot()
+	if m == nil {
+		m = map[K]V{}
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes a JSON object and replaces the contents of the map
+// with it.
+func (c *COWMap[K, V]) UnmarshalJSON(b []byte) error {
+	var m map[K]V
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	c.m.Store(&m)
+	return nil
+}
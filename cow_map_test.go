@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCOWMap(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var m COWMap[string, int]
+		assert.Equal(t, 0, m.Len(), "Zero value should be empty.")
+		_, ok := m.Load("foo")
+		assert.False(t, ok, "Zero value shouldn't hold any keys.")
+		m.Delete("foo")
+		m.Range(func(string, int) bool {
+			t.Fatal("Range of an empty map called fn.")
+			return true
+		})
+	})
+
+	init := map[string]int{"foo": 1}
+	m := NewCOWMap(init)
+	init["bar"] = 2
+	assert.Equal(t, 1, m.Len(), "NewCOWMap didn't copy its argument.")
+
+	m.Store("bar", 2)
+	v, ok := m.Load("bar")
+	require.True(t, ok, "Store didn't add the key.")
+	assert.Equal(t, 2, v, "Store didn't set the value.")
+
+	m.Delete("foo")
+	_, ok = m.Load("foo")
+	assert.False(t, ok, "Delete didn't remove the key.")
+
+	m.Update("bar", func(old int, loaded bool) (int, bool) {
+		assert.True(t, loaded, "Update didn't report the existing value.")
+		return old * 10, true
+	})
+	v, _ = m.Load("bar")
+	assert.Equal(t, 20, v, "Update didn't replace the value.")
+
+	m.Update("bar", func(int, bool) (int, bool) { return 0, false })
+	assert.Equal(t, 0, m.Len(), "Update didn't delete the key.")
+
+	m.Batch(func(m map[string]int) {
+		m["a"] = 1
+		m["b"] = 2
+		m["c"] = 3
+	})
+	assert.Equal(t, 3, m.Len(), "Batch didn't apply every change.")
+
+	t.Run("Range", func(t *testing.T) {
+		got := make(map[string]int)
+		m.Range(func(k string, v int) bool {
+			got[k] = v
+			// Writes during Range don't affect the snapshot being iterated.
+			m.Store(k+k, v)
+			return true
+		})
+		assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, got, "Range didn't visit every entry.")
+
+		var visited int
+		m.Range(func(string, int) bool {
+			visited++
+			return false
+		})
+		assert.Equal(t, 1, visited, "Range didn't stop when fn returned false.")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		m := NewCOWMap(map[string]int{"a": 1})
+		bytes, err := json.Marshal(m)
+		require.NoError(t, err, "json.Marshal errored unexpectedly.")
+		assert.Equal(t, `{"a":1}`, string(bytes), "json.Marshal encoded the wrong bytes.")
+
+		require.NoError(t, json.Unmarshal([]byte(`{"b":2,"c":3}`), m), "json.Unmarshal errored unexpectedly.")
+		assert.Equal(t, 2, m.Len(), "json.Unmarshal didn't replace the contents.")
+		v, _ := m.Load("c")
+		assert.Equal(t, 3, v, "json.Unmarshal didn't set the values.")
+
+		err = json.Unmarshal([]byte(`{"b":"2"}`), m)
+		require.Error(t, err, "json.Unmarshal didn't error as expected.")
+		assertErrorJSONUnmarshalType(t, err,
+			"json.Unmarshal failed with unexpected error %v, want UnmarshalTypeError.", err)
+
+		var empty COWMap[string, int]
+		bytes, err = json.Marshal(&empty)
+		require.NoError(t, err, "json.Marshal errored unexpectedly.")
+		assert.Equal(t, `{}`, string(bytes), "json.Marshal of an empty map encoded the wrong bytes.")
+	})
+}
+
+func TestCOWMapConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 200
+	)
+
+	var m COWMap[string, int]
+	var wg sync.WaitGroup
+	wg.Add(goroutines * 2)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				m.Store(strconv.Itoa(g)+"/"+strconv.Itoa(i), i)
+				m.Update("counter", func(old int, _ bool) (int, bool) {
+					return old + 1, true
+				})
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				m.Load("counter")
+				m.Range(func(string, int) bool { return true })
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, goroutines*iterations+1, m.Len(), "Lost concurrent writes.")
+	v, _ := m.Load("counter")
+	assert.Equal(t, goroutines*iterations, v, "Lost concurrent updates.")
+}
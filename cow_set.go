@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+// COWSet is a copy-on-write set for read-mostly workloads such as
+// allowlists.
+//
+// Readers work on an immutable snapshot of the set, so Contains, Range and
+// Len are wait-free and never block writers. Every write that changes the
+// set copies it and swaps the copy in.
+//
+// The zero value is an empty set ready to use.
+type COWSet[T comparable] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	m Pointer[map[T]struct{}]
+}
+
+// NewCOWSet creates a COWSet holding the given items.
+func NewCOWSet[T comparable](items ...T) *COWSet[T] {
+	c := &COWSet[T]{}
+	if len(items) > 0 {
+		m := make(map[T]struct{}, len(items))
+		for _, item := range items {
+			m[item] = struct{}{}
+		}
+		c.m.Store(&m)
+	}
+	return c
+}
+
+// Snapshot returns the current contents of the set.
+//
+// The returned map is shared with other readers and must not be modified.
+// Later writes to the COWSet don't affect it.
+func (c *COWSet[T]) Snapshot() map[T]struct{} {
+	if m := c.m.Load(); m != nil {
+		return *m
+	}
+	return nil
+}
+
+// Contains reports whether item is in the set.
+func (c *COWSet[T]) Contains(item T) bool {
+	_, ok := c.Snapshot()[item]
+	return ok
+}
+
+// Len reports the number of items in the set.
+func (c *COWSet[T]) Len() int {
+	return len(c.Snapshot())
+}
+
+// Range calls fn for each item in the set until fn returns false. It
+// iterates over a snapshot taken when Range is called.
+func (c *COWSet[T]) Range(fn func(item T) bool) {
+	for item := range c.Snapshot() {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+// Add atomically adds item to the set, and reports whether it was absent.
+func (c *COWSet[T]) Add(item T) (added bool) {
+	c.m.Modify(func(cur *map[T]struct{}) (*map[T]struct{}, error) {
+		added = false
+		if cur != nil {
+			if _, ok := (*cur)[item]; ok {
+				// Already present, so don't bother copying.
+				return cur, nil
+			}
+		}
+		next := cloneMap(cur)
+		(*next)[item] = struct{}{}
+		added = true
+		return next, nil
+	})
+	return added
+}
+
+// Remove atomically removes item from the set, and reports whether it was
+// present.
+func (c *COWSet[T]) Remove(item T) (removed bool) {
+	c.m.Modify(func(cur *map[T]struct{}) (*map[T]struct{}, error) {
+		removed = false
+		if cur == nil {
+			return cur, nil
+		}
+		if _, ok := (*cur)[item]; !ok {
+			return cur, nil
+		}
+		next := cloneMap(cur)
+		delete(*next, item)
+		removed = true
+		return next, nil
+	})
+	return removed
+}
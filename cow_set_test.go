@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCOWSet(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var s COWSet[string]
+		assert.Equal(t, 0, s.Len(), "Zero value should be empty.")
+		assert.False(t, s.Contains("foo"), "Zero value shouldn't hold any items.")
+		assert.False(t, s.Remove("foo"), "Zero value shouldn't hold any items.")
+	})
+
+	s := NewCOWSet("foo", "bar")
+	assert.True(t, s.Contains("foo"), "NewCOWSet didn't add its arguments.")
+	assert.Equal(t, 2, s.Len(), "NewCOWSet didn't add its arguments.")
+
+	before := s.Snapshot()
+	assert.True(t, s.Add("baz"), "Add of a new item should report true.")
+	assert.False(t, s.Add("baz"), "Add of an existing item should report false.")
+	assert.True(t, s.Contains("baz"), "Add didn't add the item.")
+	assert.Len(t, before, 2, "Add changed an older snapshot.")
+
+	assert.True(t, s.Remove("foo"), "Remove of an existing item should report true.")
+	assert.False(t, s.Remove("foo"), "Remove of a missing item should report false.")
+	assert.False(t, s.Contains("foo"), "Remove didn't remove the item.")
+	assert.Equal(t, map[string]struct{}{"bar": {}, "baz": {}}, s.Snapshot(), "Snapshot returned the wrong items.")
+
+	var n int
+	s.Range(func(string) bool {
+		n++
+		return false
+	})
+	assert.Equal(t, 1, n, "Range didn't stop when fn returned false.")
+}
+
+func TestCOWSetConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 200
+	)
+
+	var (
+		s     COWSet[int]
+		added Int64
+		wg    sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				if s.Add(i) {
+					added.Inc()
+				}
+				s.Contains(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(iterations), added.Load(), "Add reported the wrong number of new items.")
+	assert.Equal(t, iterations, s.Len(), "Lost concurrent writes.")
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+// COWSlice is a copy-on-write slice for read-mostly workloads such as
+// listener lists.
+//
+// Readers work on an immutable snapshot of the slice, so Snapshot, Range
+// and Len are wait-free and never block writers. Every write copies the
+// whole slice and swaps the copy in.
+//
+// The zero value is an empty slice ready to use.
+type COWSlice[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	s Pointer[[]T]
+}
+
+// NewCOWSlice creates a COWSlice holding a copy of the given items.
+func NewCOWSlice[T any](items ...T) *COWSlice[T] {
+	c := &COWSlice[T]{}
+	if len(items) > 0 {
+		s := append([]T(nil), items...)
+		c.s.Store(&s)
+	}
+	return c
+}
+
+// Snapshot returns the current contents of the slice.
+//
+// The returned slice is shared with other readers and must not be
+// modified. Later writes to the COWSlice don't affect it.
+func (c *COWSlice[T]) Snapshot() []T {
+	if s := c.s.Load(); s != nil {
+		return *s
+	}
+	return nil
+}
+
+// Len reports the number of items in the slice.
+func (c *COWSlice[T]) Len() int {
+	return len(c.Snapshot())
+}
+
+// Range calls fn for each index and item in the slice, in order, until fn
+// returns false. It iterates over a snapshot taken when Range is called.
+func (c *COWSlice[T]) Range(fn func(i int, item T) bool) {
+	for i, item := range c.Snapshot() {
+		if !fn(i, item) {
+			return
+		}
+	}
+}
+
+// Append atomically adds items to the end of the slice.
+func (c *COWSlice[T]) Append(items ...T) {
+	if len(items) == 0 {
+		return
+	}
+	c.s.Modify(func(cur *[]T) (*[]T, error) {
+		var old []T
+		if cur != nil {
+			old = *cur
+		}
+		next := make([]T, 0, len(old)+len(items))
+		next = append(next, old...)
+		next = append(next, items...)
+		return &next, nil
+	})
+}
+
+// RemoveFunc atomically removes every item for which fn returns true, and
+// reports how many it removed.
+//
+// fn may be called several times for the same item if other goroutines
+// write to the slice concurrently, so it must not have side effects.
+func (c *COWSlice[T]) RemoveFunc(fn func(item T) bool) (removed int) {
+	c.s.Modify(func(cur *[]T) (*[]T, error) {
+		removed = 0
+		if cur == nil {
+			return cur, nil
+		}
+		var next []T
+		for i, item := range *cur {
+			if !fn(item) {
+				if next != nil {
+					next = append(next, item)
+				}
+				continue
+			}
+			if next == nil {
+				next = make([]T, i, len(*cur)-1)
+				copy(next, (*cur)[:i])
+			}
+			removed++
+		}
+		if removed == 0 {
+			// Nothing to remove, so don't bother copying.
+			return cur, nil
+		}
+		return &next, nil
+	})
+	return removed
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCOWSlice(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var s COWSlice[int]
+		assert.Equal(t, 0, s.Len(), "Zero value should be empty.")
+		assert.Equal(t, 0, s.RemoveFunc(func(int) bool { return true }), "Zero value shouldn't hold any items.")
+		s.Append(1)
+		assert.Equal(t, []int{1}, s.Snapshot(), "Append to the zero value didn't work.")
+	})
+
+	items := []int{1, 2, 3}
+	s := NewCOWSlice(items...)
+	items[0] = 100
+	assert.Equal(t, []int{1, 2, 3}, s.Snapshot(), "NewCOWSlice didn't copy its arguments.")
+
+	before := s.Snapshot()
+	s.Append(4, 5, 6)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, s.Snapshot(), "Append didn't add items in order.")
+	assert.Equal(t, []int{1, 2, 3}, before, "Append changed an older snapshot.")
+
+	before = s.Snapshot()
+	removed := s.RemoveFunc(func(i int) bool { return i%2 == 0 })
+	assert.Equal(t, 3, removed, "RemoveFunc reported the wrong count.")
+	assert.Equal(t, []int{1, 3, 5}, s.Snapshot(), "RemoveFunc didn't remove the right items.")
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, before, "RemoveFunc changed an older snapshot.")
+
+	before = s.Snapshot()
+	assert.Equal(t, 0, s.RemoveFunc(func(i int) bool { return i > 10 }), "RemoveFunc removed unmatched items.")
+	assert.True(t, &before[0] == &s.Snapshot()[0], "RemoveFunc copied the slice without removing anything.")
+
+	var got []int
+	s.Range(func(i, item int) bool {
+		assert.Equal(t, len(got), i, "Range passed the wrong index.")
+		got = append(got, item)
+		return item < 3
+	})
+	assert.Equal(t, []int{1, 3}, got, "Range didn't stop when fn returned false.")
+
+	assert.Equal(t, 3, s.RemoveFunc(func(int) bool { return true }), "RemoveFunc didn't remove every item.")
+	assert.Equal(t, 0, s.Len(), "RemoveFunc of every item left items behind.")
+}
+
+func TestCOWSliceConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 200
+	)
+
+	var s COWSlice[int]
+	var wg sync.WaitGroup
+	wg.Add(goroutines * 2)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				s.Append(g, -g-1)
+				s.RemoveFunc(func(item int) bool { return item == -g-1 })
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				s.Range(func(int, int) bool { return true })
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, goroutines*iterations, s.Len(), "Lost concurrent writes.")
+	s.Range(func(_, item int) bool {
+		assert.True(t, item >= 0, "RemoveFunc missed an item.")
+		return true
+	})
+}
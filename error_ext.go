@@ -21,19 +21,8 @@
 package atomic
 
 // atomic.Value panics on nil inputs, or if the underlying type changes.
-// Stabilize by always storing a custom struct that we control.
+// Stabilize by always storing a custom struct that we control: packError
+// and unpackError wrap the error in a packedValue on Go 1.18 and newer, and
+// in a packedError before that.
 
 //go:generate bin/gen-atomicwrapper -name=Error -type=error -wrapped=Value -pack=packError -unpack=unpackError -compareandswap -swap -file=error.go
-
-type packedError struct{ Value error }
-
-func packError(v error) interface{} {
-	return packedError{v}
-}
-
-func unpackError(v interface{}) error {
-	if err, ok := v.(packedError); ok {
-		return err.Value
-	}
-	return nil
-}
@@ -0,0 +1,143 @@
+// @generated Code generated by gen-atomicarray.
+
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Float64Array is a fixed-length array of float64s with atomic access to each
+// element. Bulk operations such as AddVector and Snapshot are atomic per
+// element, not for the array as a whole.
+//
+// The zero value is an empty array.
+type Float64Array struct {
+	_ nocmp // disallow non-atomic comparison
+
+	stride int
+	v      []Float64
+}
+
+// NewFloat64Array creates a Float64Array of n zeroes.
+func NewFloat64Array(n int, opts ...ArrayOption) *Float64Array {
+	stride := arrayStride(n, opts)
+	return &Float64Array{stride: stride, v: make([]Float64, n*stride)}
+}
+
+func (a *Float64Array) at(i int) *Float64 {
+	return &a.v[i*a.stride]
+}
+
+// Len returns the number of elements in the array.
+func (a *Float64Array) Len() int {
+	if a.stride == 0 {
+		return 0
+	}
+	return len(a.v) / a.stride
+}
+
+// Load atomically loads element i.
+func (a *Float64Array) Load(i int) float64 {
+	return a.at(i).Load()
+}
+
+// Store atomically stores val into element i.
+func (a *Float64Array) Store(i int, val float64) {
+	a.at(i).Store(val)
+}
+
+// Add atomically adds delta to element i and returns the new value.
+func (a *Float64Array) Add(i int, delta float64) float64 {
+	return a.at(i).Add(delta)
+}
+
+// Swap atomically stores val into element i and returns the old value.
+func (a *Float64Array) Swap(i int, val float64) (old float64) {
+	return a.at(i).Swap(val)
+}
+
+// CompareAndSwap is an atomic compare-and-swap for element i.
+//
+// Like Float64.CompareAndSwap, it compares the bits of the values, so a
+// stored NaN compares equal to a passed in NaN.
+func (a *Float64Array) CompareAndSwap(i int, old, new float64) (swapped bool) {
+	return a.at(i).CompareAndSwap(old, new)
+}
+
+// AddVector atomically adds deltas[i] to each element i. It panics if
+// deltas isn't the same length as the array.
+func (a *Float64Array) AddVector(deltas []float64) {
+	checkArrayLen("Float64Array", a.Len(), len(deltas))
+	for i, d := range deltas {
+		if d != 0 {
+			a.at(i).Add(d)
+		}
+	}
+}
+
+// Snapshot loads every element into a new slice.
+func (a *Float64Array) Snapshot() []float64 {
+	s := make([]float64, a.Len())
+	for i := range s {
+		s[i] = a.at(i).Load()
+	}
+	return s
+}
+
+// Reset stores zero into every element.
+func (a *Float64Array) Reset() {
+	for i, n := 0, a.Len(); i < n; i++ {
+		a.at(i).Store(0)
+	}
+}
+
+// MarshalJSON encodes a snapshot of the array into a JSON array.
+func (a *Float64Array) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Snapshot())
+}
+
+// UnmarshalJSON decodes a JSON array into the array, which must have the
+// same length. An empty array is instead resized to fit, keeping the
+// padding it was created with, which isn't safe to do concurrently with
+// other operations.
+func (a *Float64Array) UnmarshalJSON(b []byte) error {
+	var v []float64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if a.Len() == 0 && len(v) > 0 {
+		stride := a.stride
+		if stride == 0 {
+			stride = 1 // zero value
+		}
+		*a = Float64Array{stride: stride, v: make([]Float64, len(v)*stride)}
+	}
+	if len(v) != a.Len() {
+		return fmt.Errorf("atomic: cannot decode %d values into Float64Array of length %d", len(v), a.Len())
+	}
+	for i, val := range v {
+		a.at(i).Store(val)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"encoding/json"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFloat64Array(t *testing.T) {
+	a := NewFloat64Array(3, WithCacheLinePadding())
+	require.Equal(t, 3, a.Len(), "Len didn't match.")
+
+	a.Store(0, 4.2)
+	assert.Equal(t, 4.2, a.Load(0), "Store didn't set the correct value.")
+	assert.Equal(t, 4.5, a.Add(0, 0.3), "Add didn't work.")
+	assert.Equal(t, 4.5, a.Swap(0, math.NaN()), "Swap didn't return the old value.")
+	assert.True(t, a.CompareAndSwap(0, math.NaN(), 1), "CAS of NaN didn't report a swap.")
+
+	a.AddVector([]float64{0.5, -1, 0})
+	assert.Equal(t, []float64{1.5, -1, 0}, a.Snapshot(), "AddVector didn't add to every element.")
+
+	bytes, err := json.Marshal(a)
+	require.NoError(t, err, "json.Marshal errored unexpectedly.")
+	assert.Equal(t, "[1.5,-1,0]", string(bytes), "json.Marshal encoded the wrong bytes.")
+	require.NoError(t, json.Unmarshal([]byte("[0.25,0,1]"), a), "json.Unmarshal errored unexpectedly.")
+	assert.Equal(t, []float64{0.25, 0, 1}, a.Snapshot(), "json.Unmarshal didn't set the correct values.")
+
+	a.Reset()
+	assert.Equal(t, []float64{0, 0, 0}, a.Snapshot(), "Reset didn't zero every element.")
+}
+
+func TestFloat64ArrayConcurrentAddVector(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 1000
+	)
+
+	// Hogwild-style: many goroutines applying gradient updates at once.
+	a := NewFloat64Array(2)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				a.AddVector([]float64{0.5, -0.25})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []float64{goroutines * iterations * 0.5, goroutines * iterations * -0.25},
+		a.Snapshot(), "Lost concurrent updates.")
+}
@@ -0,0 +1,467 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import "math/bits"
+
+const (
+	_hamtBits  = 5
+	_hamtWidth = 1 << _hamtBits
+	_hamtMask  = _hamtWidth - 1
+)
+
+// HAMTMap is a persistent hash array mapped trie for large read-mostly maps.
+//
+// The trie is immutable: each write copies only the O(log n) nodes on the
+// path to the changed entry and atomically swaps in a new root, sharing the
+// rest of the structure with the previous version. Reads are lock-free and
+// never block writers, and Snapshot returns a consistent view of the map
+// that may be held indefinitely.
+//
+// The zero value is not usable; create HAMTMaps with NewHAMTMap.
+type HAMTMap[K comparable, V any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	hash func(K) uint64
+	root Pointer[hamtRoot[K, V]]
+}
+
+// HAMTSnapshot is an immutable view of a HAMTMap at a point in time.
+type HAMTSnapshot[K comparable, V any] struct {
+	hash func(K) uint64
+	root *hamtRoot[K, V]
+}
+
+// HAMTDiff describes a key that differs between two snapshots.
+//
+// HasOld and HasNew report whether the key is present in the older and
+// newer snapshot: a key that was added has no Old value, and a key that was
+// removed has no New value.
+type HAMTDiff[K comparable, V any] struct {
+	Key    K
+	Old    V
+	New    V
+	HasOld bool
+	HasNew bool
+}
+
+type hamtRoot[K comparable, V any] struct {
+	node *hamtNode[K, V]
+	size int
+}
+
+// hamtNode is an interior node. bitmap has a bit set for each of the 32
+// possible children that is present, and entries holds them in order.
+type hamtNode[K comparable, V any] struct {
+	bitmap  uint32
+	entries []hamtEntry[K, V]
+}
+
+// hamtEntry is either a subtree or a leaf.
+type hamtEntry[K comparable, V any] struct {
+	node *hamtNode[K, V]
+	leaf *hamtLeaf[K, V]
+}
+
+// hamtLeaf holds every key with a given hash. There's usually one; there
+// are more only if the hash function has full 64-bit collisions.
+type hamtLeaf[K comparable, V any] struct {
+	hash uint64
+	kvs  []*hamtKV[K, V]
+}
+
+// hamtKV is never modified once published, so a pointer comparison tells
+// whether the entry was written between two snapshots.
+type hamtKV[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewHAMTMap creates an empty HAMTMap that hashes keys with the given
+// function. Hashes should be spread evenly over all 64 bits.
+func NewHAMTMap[K comparable, V any](hash func(K) uint64) *HAMTMap[K, V] {
+	if hash == nil {
+		panic("atomic: HAMTMap requires a hash function")
+	}
+	return &HAMTMap[K, V]{hash: hash}
+}
+
+// Snapshot returns an immutable view of the current contents of the map.
+func (m *HAMTMap[K, V]) Snapshot() *HAMTSnapshot[K, V] {
+	return &HAMTSnapshot[K, V]{hash: m.hash, root: m.root.Load()}
+}
+
+// Load returns the value stored for key, if any.
+func (m *HAMTMap[K, V]) Load(key K) (value V, ok bool) {
+	return m.Snapshot().Load(key)
+}
+
+// Len reports the number of entries in the map.
+func (m *HAMTMap[K, V]) Len() int {
+	return m.Snapshot().Len()
+}
+
+// Range calls fn for each key and value in the map until fn returns false.
+// It iterates over a snapshot of the map taken when Range is called.
+func (m *HAMTMap[K, V]) Range(fn func(key K, value V) bool) {
+	m.Snapshot().Range(fn)
+}
+
+// Store sets the value for key.
+func (m *HAMTMap[K, V]) Store(key K, value V) {
+	h := m.hash(key)
+	kv := &hamtKV[K, V]{key: key, value: value}
+	m.root.Modify(func(cur *hamtRoot[K, V]) (*hamtRoot[K, V], error) {
+		next := &hamtRoot[K, V]{}
+		if cur != nil {
+			*next = *cur
+		}
+		var added bool
+		next.node, added = hamtPut(next.node, 0, h, kv)
+		if added {
+			next.size++
+		}
+		return next, nil
+	})
+}
+
+// Delete removes the value for key, if any.
+func (m *HAMTMap[K, V]) Delete(key K) {
+	h := m.hash(key)
+	m.root.Modify(func(cur *hamtRoot[K, V]) (*hamtRoot[K, V], error) {
+		if cur == nil {
+			return cur, nil
+		}
+		node, removed := hamtRemove(cur.node, 0, h, key)
+		if !removed {
+			return cur, nil
+		}
+		return &hamtRoot[K, V]{node: node, size: cur.size - 1}, nil
+	})
+}
+
+// Load returns the value stored for key in the snapshot, if any.
+func (s *HAMTSnapshot[K, V]) Load(key K) (value V, ok bool) {
+	if s.root == nil {
+		return value, false
+	}
+	h := s.hash(key)
+	n := s.root.node
+	for shift := uint(0); n != nil; shift += _hamtBits {
+		bit := hamtBit(h, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		e := n.entries[n.index(bit)]
+		if e.node != nil {
+			n = e.node
+			continue
+		}
+		if e.leaf.hash == h {
+			if i := e.leaf.find(key); i >= 0 {
+				return e.leaf.kvs[i].value, true
+			}
+		}
+		break
+	}
+	return value, false
+}
+
+// Len reports the number of entries in the snapshot.
+func (s *HAMTSnapshot[K, V]) Len() int {
+	if s.root == nil {
+		return 0
+	}
+	return s.root.size
+}
+
+// Range calls fn for each key and value in the snapshot until fn returns
+// false. The iteration order is unspecified.
+func (s *HAMTSnapshot[K, V]) Range(fn func(key K, value V) bool) {
+	if s.root != nil {
+		s.root.node.each(func(kv *hamtKV[K, V]) bool {
+			return fn(kv.key, kv.value)
+		})
+	}
+}
+
+// Diff calls fn for each key that differs between s and the newer snapshot
+// until fn returns false.
+//
+// Diff skips subtrees that the two snapshots share, so it runs in time
+// proportional to the number of writes between them rather than the size of
+// the map. Both snapshots must come from the same HAMTMap. A key that was
+// stored again is reported as changed even if its value is the same.
+func (s *HAMTSnapshot[K, V]) Diff(newer *HAMTSnapshot[K, V], fn func(d HAMTDiff[K, V]) bool) {
+	var a, b *hamtNode[K, V]
+	if s.root != nil {
+		a = s.root.node
+	}
+	if newer.root != nil {
+		b = newer.root.node
+	}
+	hamtDiffNodes(a, b, fn)
+}
+
+func hamtBit(h uint64, shift uint) uint32 {
+	return 1 << ((h >> shift) & _hamtMask)
+}
+
+// index returns the position in entries of the child with the given bit.
+func (n *hamtNode[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hamtNode[K, V]) each(fn func(*hamtKV[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, e := range n.entries {
+		if !e.each(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e hamtEntry[K, V]) each(fn func(*hamtKV[K, V]) bool) bool {
+	if e.node != nil {
+		return e.node.each(fn)
+	}
+	for _, kv := range e.leaf.kvs {
+		if !fn(kv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *hamtLeaf[K, V]) find(key K) int {
+	for i, kv := range l.kvs {
+		if kv.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// hamtPut returns a copy of n with kv stored in it, and whether kv's key
+// was newly added. n may be nil.
+func hamtPut[K comparable, V any](n *hamtNode[K, V], shift uint, h uint64, kv *hamtKV[K, V]) (_ *hamtNode[K, V], added bool) {
+	if n == nil {
+		n = &hamtNode[K, V]{}
+	}
+	bit := hamtBit(h, shift)
+	idx := n.index(bit)
+	if n.bitmap&bit == 0 {
+		leaf := &hamtLeaf[K, V]{hash: h, kvs: []*hamtKV[K, V]{kv}}
+		entries := make([]hamtEntry[K, V], len(n.entries)+1)
+		copy(entries, n.entries[:idx])
+		entries[idx] = hamtEntry[K, V]{leaf: leaf}
+		copy(entries[idx+1:], n.entries[idx:])
+		return &hamtNode[K, V]{bitmap: n.bitmap | bit, entries: entries}, true
+	}
+
+	var next hamtEntry[K, V]
+	switch e := n.entries[idx]; {
+	case e.node != nil:
+		next.node, added = hamtPut(e.node, shift+_hamtBits, h, kv)
+	case e.leaf.hash == h:
+		leaf := &hamtLeaf[K, V]{hash: h}
+		if i := e.leaf.find(kv.key); i >= 0 {
+			leaf.kvs = append(leaf.kvs, e.leaf.kvs...)
+			leaf.kvs[i] = kv
+		} else {
+			leaf.kvs = append(leaf.kvs, e.leaf.kvs...)
+			leaf.kvs = append(leaf.kvs, kv)
+			added = true
+		}
+		next.leaf = leaf
+	default:
+		leaf := &hamtLeaf[K, V]{hash: h, kvs: []*hamtKV[K, V]{kv}}
+		next.node = hamtSplit(shift+_hamtBits, e.leaf, leaf)
+		added = true
+	}
+	return n.replace(idx, next), added
+}
+
+// hamtSplit builds a subtree holding two leaves with different hashes.
+func hamtSplit[K comparable, V any](shift uint, a, b *hamtLeaf[K, V]) *hamtNode[K, V] {
+	abit, bbit := hamtBit(a.hash, shift), hamtBit(b.hash, shift)
+	switch {
+	case abit == bbit:
+		return &hamtNode[K, V]{
+			bitmap:  abit,
+			entries: []hamtEntry[K, V]{{node: hamtSplit(shift+_hamtBits, a, b)}},
+		}
+	case abit < bbit:
+		return &hamtNode[K, V]{
+			bitmap:  abit | bbit,
+			entries: []hamtEntry[K, V]{{leaf: a}, {leaf: b}},
+		}
+	default:
+		return &hamtNode[K, V]{
+			bitmap:  abit | bbit,
+			entries: []hamtEntry[K, V]{{leaf: b}, {leaf: a}},
+		}
+	}
+}
+
+// hamtRemove returns a copy of n without key, and whether key was present.
+// It returns nil if the node would be left empty.
+func hamtRemove[K comparable, V any](n *hamtNode[K, V], shift uint, h uint64, key K) (_ *hamtNode[K, V], removed bool) {
+	if n == nil {
+		return nil, false
+	}
+	bit := hamtBit(h, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	idx := n.index(bit)
+
+	var next hamtEntry[K, V]
+	switch e := n.entries[idx]; {
+	case e.node != nil:
+		child, removed := hamtRemove(e.node, shift+_hamtBits, h, key)
+		if !removed {
+			return n, false
+		}
+		switch {
+		case child == nil:
+			return n.remove(idx, bit), true
+		case len(child.entries) == 1 && child.entries[0].leaf != nil:
+			// Pull lone leaves up so that the trie stays shallow.
+			next.leaf = child.entries[0].leaf
+		default:
+			next.node = child
+		}
+	case e.leaf.hash == h:
+		i := e.leaf.find(key)
+		if i < 0 {
+			return n, false
+		}
+		if len(e.leaf.kvs) == 1 {
+			return n.remove(idx, bit), true
+		}
+		leaf := &hamtLeaf[K, V]{hash: h, kvs: make([]*hamtKV[K, V], 0, len(e.leaf.kvs)-1)}
+		leaf.kvs = append(leaf.kvs, e.leaf.kvs[:i]...)
+		leaf.kvs = append(leaf.kvs, e.leaf.kvs[i+1:]...)
+		next.leaf = leaf
+	default:
+		return n, false
+	}
+	return n.replace(idx, next), true
+}
+
+func (n *hamtNode[K, V]) replace(idx int, e hamtEntry[K, V]) *hamtNode[K, V] {
+	entries := make([]hamtEntry[K, V], len(n.entries))
+	copy(entries, n.entries)
+	entries[idx] = e
+	return &hamtNode[K, V]{bitmap: n.bitmap, entries: entries}
+}
+
+func (n *hamtNode[K, V]) remove(idx int, bit uint32) *hamtNode[K, V] {
+	if len(n.entries) == 1 {
+		return nil
+	}
+	entries := make([]hamtEntry[K, V], 0, len(n.entries)-1)
+	entries = append(entries, n.entries[:idx]...)
+	entries = append(entries, n.entries[idx+1:]...)
+	return &hamtNode[K, V]{bitmap: n.bitmap &^ bit, entries: entries}
+}
+
+func hamtDiffNodes[K comparable, V any](a, b *hamtNode[K, V], fn func(HAMTDiff[K, V]) bool) bool {
+	if a == b {
+		return true
+	}
+	var abits, bbits uint32
+	if a != nil {
+		abits = a.bitmap
+	}
+	if b != nil {
+		bbits = b.bitmap
+	}
+	for all := abits | bbits; all != 0; all &= all - 1 {
+		bit := all & -all
+		var ea, eb hamtEntry[K, V]
+		if abits&bit != 0 {
+			ea = a.entries[a.index(bit)]
+		}
+		if bbits&bit != 0 {
+			eb = b.entries[b.index(bit)]
+		}
+		if !hamtDiffEntries(ea, eb, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func hamtDiffEntries[K comparable, V any](a, b hamtEntry[K, V], fn func(HAMTDiff[K, V]) bool) bool {
+	switch {
+	case a == b:
+		// Shared with the other snapshot, so nothing under it changed.
+		return true
+	case a.node != nil && b.node != nil:
+		return hamtDiffNodes(a.node, b.node, fn)
+	case a.node == nil && a.leaf == nil:
+		return b.each(func(kv *hamtKV[K, V]) bool {
+			return fn(HAMTDiff[K, V]{Key: kv.key, New: kv.value, HasNew: true})
+		})
+	case b.node == nil && b.leaf == nil:
+		return a.each(func(kv *hamtKV[K, V]) bool {
+			return fn(HAMTDiff[K, V]{Key: kv.key, Old: kv.value, HasOld: true})
+		})
+	}
+
+	// The subtrees have different shapes, so match their keys directly.
+	old := make(map[K]*hamtKV[K, V])
+	a.each(func(kv *hamtKV[K, V]) bool {
+		old[kv.key] = kv
+		return true
+	})
+	ok := b.each(func(kv *hamtKV[K, V]) bool {
+		o, found := old[kv.key]
+		if !found {
+			return fn(HAMTDiff[K, V]{Key: kv.key, New: kv.value, HasNew: true})
+		}
+		delete(old, kv.key)
+		if o == kv {
+			return true
+		}
+		return fn(HAMTDiff[K, V]{
+			Key: kv.key, Old: o.value, New: kv.value,
+			HasOld: true, HasNew: true,
+		})
+	})
+	if !ok {
+		return false
+	}
+	for _, o := range old {
+		if !fn(HAMTDiff[K, V]{Key: o.key, Old: o.value, HasOld: true}) {
+			return false
+		}
+	}
+	return true
+}
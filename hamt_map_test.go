@@ -0,0 +1,189 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hashString(s string) uint64 {
+	h, _ := hashKey([]byte(s))
+	return h
+}
+
+func TestHAMTMap(t *testing.T) {
+	tests := []struct {
+		desc string
+		hash func(string) uint64
+	}{
+		{desc: "well distributed", hash: hashString},
+		// Only 8 distinct hashes differing in the top bits, so every
+		// level of the trie and the collision lists get exercised.
+		{desc: "colliding", hash: func(s string) uint64 { return hashString(s) & 7 << 61 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			const n = 1000
+			m := NewHAMTMap[string, int](tt.hash)
+			want := make(map[string]int)
+
+			for i := 0; i < n; i++ {
+				m.Store(strconv.Itoa(i), i)
+				want[strconv.Itoa(i)] = i
+			}
+			require.Equal(t, n, m.Len(), "Len didn't count every Store.")
+
+			m.Store("7", 700)
+			want["7"] = 700
+			require.Equal(t, n, m.Len(), "Overwriting a key changed Len.")
+
+			for i := 0; i < n; i += 2 {
+				m.Delete(strconv.Itoa(i))
+				delete(want, strconv.Itoa(i))
+			}
+			m.Delete("missing")
+			require.Equal(t, len(want), m.Len(), "Len didn't account for Delete.")
+
+			for i := 0; i < n; i++ {
+				v, ok := m.Load(strconv.Itoa(i))
+				wv, wok := want[strconv.Itoa(i)]
+				assert.Equal(t, wok, ok, "Load reported the wrong presence for %d.", i)
+				assert.Equal(t, wv, v, "Load returned the wrong value for %d.", i)
+			}
+
+			got := make(map[string]int)
+			m.Range(func(k string, v int) bool {
+				got[k] = v
+				return true
+			})
+			assert.Equal(t, want, got, "Range didn't visit every entry.")
+
+			for k := range want {
+				m.Delete(k)
+			}
+			assert.Equal(t, 0, m.Len(), "Deleting every key didn't empty the map.")
+			assert.Nil(t, m.Snapshot().root.node, "Deleting every key left nodes behind.")
+		})
+	}
+
+	t.Run("panics", func(t *testing.T) {
+		assert.Panics(t, func() { NewHAMTMap[string, int](nil) }, "NewHAMTMap should panic without a hash.")
+	})
+}
+
+func TestHAMTSnapshot(t *testing.T) {
+	m := NewHAMTMap[string, int](hashString)
+	empty := m.Snapshot()
+	assert.Equal(t, 0, empty.Len(), "Snapshot of an empty map should be empty.")
+	_, ok := empty.Load("foo")
+	assert.False(t, ok, "Snapshot of an empty map shouldn't hold any keys.")
+
+	for i := 0; i < 100; i++ {
+		m.Store(strconv.Itoa(i), i)
+	}
+	before := m.Snapshot()
+
+	m.Store("5", 500)
+	m.Store("100", 100)
+	m.Delete("6")
+	m.Store("7", 7) // same value, still reported
+
+	assert.Equal(t, 100, before.Len(), "Writes changed an older snapshot.")
+	v, _ := before.Load("5")
+	assert.Equal(t, 5, v, "Writes changed an older snapshot.")
+
+	var diffs []HAMTDiff[string, int]
+	before.Diff(m.Snapshot(), func(d HAMTDiff[string, int]) bool {
+		diffs = append(diffs, d)
+		return true
+	})
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Key < diffs[j].Key })
+	assert.Equal(t, []HAMTDiff[string, int]{
+		{Key: "100", New: 100, HasNew: true},
+		{Key: "5", Old: 5, New: 500, HasOld: true, HasNew: true},
+		{Key: "6", Old: 6, HasOld: true},
+		{Key: "7", Old: 7, New: 7, HasOld: true, HasNew: true},
+	}, diffs, "Diff reported the wrong changes.")
+
+	var added int
+	empty.Diff(before, func(d HAMTDiff[string, int]) bool {
+		assert.True(t, d.HasNew && !d.HasOld, "Diff from empty should only add keys.")
+		added++
+		return true
+	})
+	assert.Equal(t, 100, added, "Diff from empty didn't report every key.")
+
+	var calls int
+	before.Diff(empty, func(HAMTDiff[string, int]) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, 1, calls, "Diff didn't stop when fn returned false.")
+
+	before.Diff(before, func(HAMTDiff[string, int]) bool {
+		t.Fatal("Diff of a snapshot against itself reported a change.")
+		return true
+	})
+}
+
+func TestHAMTMapConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 200
+	)
+
+	m := NewHAMTMap[string, int](hashString)
+	var wg sync.WaitGroup
+	wg.Add(goroutines * 2)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				m.Store(strconv.Itoa(g)+"/"+strconv.Itoa(i), i)
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				s := m.Snapshot()
+				var n int
+				s.Range(func(string, int) bool {
+					n++
+					return true
+				})
+				assert.Equal(t, s.Len(), n, "Snapshot Len disagreed with Range.")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, goroutines*iterations, m.Len(), "Lost concurrent writes.")
+}
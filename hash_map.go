@@ -0,0 +1,428 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+const (
+	_hashMapMinCapacity = 16
+	_hashMapCopyChunk   = 64
+)
+
+// HashMap is a lock-free concurrent hash map using open addressing with
+// linear probing, after Cliff Click's non-blocking hash table.
+//
+// Each slot holds an immutable entry that is replaced with
+// compare-and-swap. Once a key claims a slot it keeps it, and deleting the
+// key leaves a tombstone behind until the table is next resized.
+//
+// Resizing is incremental and cooperative. The goroutine that finds the
+// table too full allocates a larger one, and every write while a resize is
+// in progress copies a chunk of slots before doing its own work. Copying a
+// slot first freezes it by replacing its entry with a "moved" copy, which
+// sends later writes for that key to the new table, then inserts the
+// frozen value there unless a newer write got there first. Reads never
+// wait: a read that finds a frozen entry looks for a newer value in the
+// new table and falls back to the frozen one.
+//
+// The zero value is not usable; create HashMaps with NewHashMap.
+type HashMap[K comparable, V any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	// len comes first so that it's 8-byte aligned on 32-bit platforms.
+	len  Int64
+	hash func(K) uint64
+	root Pointer[hashTable[K, V]]
+}
+
+type hashTable[K comparable, V any] struct {
+	// The 64-bit counters come first so that they're 8-byte aligned on
+	// 32-bit platforms.
+	used    Int64 // slots holding a key, including tombstones
+	claimed Int64 // slots handed out to copying goroutines
+	copied  Int64 // slots frozen so far
+
+	mask  uint64
+	slots []Pointer[hashEntry[K, V]]
+
+	// Set once the table is being copied into a larger one.
+	next Pointer[hashTable[K, V]]
+
+	// movedEmpty replaces empty slots during a copy so that no new keys
+	// are added to them.
+	movedEmpty *hashEntry[K, V]
+}
+
+type hashEntry[K comparable, V any] struct {
+	key   K
+	hash  uint64
+	val   *V   // nil for a tombstone
+	moved bool // frozen; newer values for key are in the next table
+}
+
+// NewHashMap creates an empty HashMap that hashes keys with the given
+// function. Hashes should be spread evenly over all 64 bits.
+func NewHashMap[K comparable, V any](hash func(K) uint64) *HashMap[K, V] {
+	if hash == nil {
+		panic("atomic: HashMap requires a hash function")
+	}
+	m := &HashMap[K, V]{hash: hash}
+	m.root.Store(newHashTable[K, V](_hashMapMinCapacity))
+	return m
+}
+
+func newHashTable[K comparable, V any](capacity int) *hashTable[K, V] {
+	return &hashTable[K, V]{
+		mask:       uint64(capacity - 1),
+		slots:      make([]Pointer[hashEntry[K, V]], capacity),
+		movedEmpty: &hashEntry[K, V]{moved: true},
+	}
+}
+
+// Load returns the value stored for key, if any.
+func (m *HashMap[K, V]) Load(key K) (value V, ok bool) {
+	if v, _ := m.root.Load().load(key, m.hash(key)); v != nil {
+		return *v, true
+	}
+	return value, false
+}
+
+// Store sets the value for key.
+func (m *HashMap[K, V]) Store(key K, value V) {
+	m.mutate(key, func(*V) (*V, bool) {
+		return &value, true
+	})
+}
+
+// LoadOrStore returns the existing value for key if present. Otherwise, it
+// stores and returns the given value. loaded reports whether the value was
+// already present.
+func (m *HashMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	prev, wrote := m.mutate(key, func(cur *V) (*V, bool) {
+		return &value, cur == nil
+	})
+	if !wrote {
+		return *prev, true
+	}
+	return value, false
+}
+
+// LoadAndDelete removes key from the map and returns its value, if it was
+// present.
+func (m *HashMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	prev, wrote := m.mutate(key, func(cur *V) (*V, bool) {
+		return nil, cur != nil
+	})
+	if !wrote {
+		return value, false
+	}
+	return *prev, true
+}
+
+// Delete removes key from the map, if present.
+func (m *HashMap[K, V]) Delete(key K) {
+	m.LoadAndDelete(key)
+}
+
+// CompareAndSwap stores new for key if its current value is equal to old,
+// and reports whether it did. Like sync.Map, it panics if the values are
+// not comparable.
+func (m *HashMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	_, swapped = m.mutate(key, func(cur *V) (*V, bool) {
+		return &new, cur != nil && interface{}(*cur) == interface{}(old)
+	})
+	return swapped
+}
+
+// CompareAndDelete removes key if its current value is equal to old, and
+// reports whether it did. Like sync.Map, it panics if the values are not
+// comparable.
+func (m *HashMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	_, deleted = m.mutate(key, func(cur *V) (*V, bool) {
+		return nil, cur != nil && interface{}(*cur) == interface{}(old)
+	})
+	return deleted
+}
+
+// Range calls fn for each key and value in the map until fn returns false.
+//
+// Like sync.Map's Range, it doesn't correspond to a consistent snapshot:
+// it visits each key at most once, and visits every key present for the
+// whole call, but may or may not see writes made while it runs. Range
+// finishes any resize in progress before it starts.
+func (m *HashMap[K, V]) Range(fn func(key K, value V) bool) {
+	t := m.root.Load()
+	for next := t.next.Load(); next != nil; next = t.next.Load() {
+		m.finishCopy(t, next)
+		t = next
+	}
+
+	for i := range t.slots {
+		e := t.slots[i].Load()
+		if e == nil || e == t.movedEmpty {
+			continue
+		}
+		val := e.val
+		if e.moved {
+			// A new resize started; find the latest value.
+			if v, found := t.next.Load().load(e.key, e.hash); found {
+				val = v
+			}
+		}
+		if val != nil && !fn(e.key, *val) {
+			return
+		}
+	}
+}
+
+// Len reports the number of entries in the map. Under concurrent use it's
+// only an approximation.
+func (m *HashMap[K, V]) Len() int {
+	if n := m.len.Load(); n > 0 {
+		return int(n)
+	}
+	return 0
+}
+
+// load returns the value for key in t or the tables it's being copied to,
+// and whether any of them had an entry for key.
+func (t *hashTable[K, V]) load(key K, h uint64) (val *V, found bool) {
+	for i, n := h&t.mask, 0; n < len(t.slots); i, n = (i+1)&t.mask, n+1 {
+		e := t.slots[i].Load()
+		switch {
+		case e == nil:
+			return nil, false
+		case e == t.movedEmpty:
+			return t.next.Load().load(key, h)
+		case e.hash != h || e.key != key:
+			continue
+		case e.moved:
+			if v, found := t.next.Load().load(key, h); found {
+				return v, true
+			}
+			// Not copied yet, so the frozen value is the latest.
+		}
+		return e.val, true
+	}
+	if next := t.next.Load(); next != nil {
+		return next.load(key, h)
+	}
+	return nil, false
+}
+
+// mutate atomically replaces the value for key with the result of fn,
+// which is given the current value, or nil if key is absent. A nil result
+// deletes key. If fn returns false, mutate leaves the map unchanged.
+//
+// mutate returns the value last given to fn and whether it was replaced.
+// fn may be called several times under contention.
+func (m *HashMap[K, V]) mutate(key K, fn func(cur *V) (next *V, write bool)) (prev *V, wrote bool) {
+	h := m.hash(key)
+	for t := m.root.Load(); ; t = t.next.Load() {
+		if next := t.next.Load(); next != nil {
+			m.helpCopy(t, next)
+		}
+		prev, wrote, done := m.mutateIn(t, key, h, fn)
+		if done {
+			return prev, wrote
+		}
+	}
+}
+
+// mutateIn is mutate for a single table. It reports false for done if the
+// caller must retry in the next table.
+func (m *HashMap[K, V]) mutateIn(t *hashTable[K, V], key K, h uint64, fn func(*V) (*V, bool)) (prev *V, wrote, done bool) {
+	for i, n := h&t.mask, 0; n < len(t.slots); {
+		slot := &t.slots[i]
+		e := slot.Load()
+		switch {
+		case e == nil:
+			next, write := fn(nil)
+			if !write || next == nil {
+				return nil, false, true
+			}
+			if t.used.Load() >= int64(len(t.slots))*3/4 {
+				m.grow(t)
+				// Freeze the slot, so that anyone looking for key here
+				// moves on to the next table too.
+				if m.copySlot(t, t.next.Load(), int(i)) {
+					m.addCopied(t, 1)
+				}
+				return nil, false, false
+			}
+			if !slot.CompareAndSwap(nil, &hashEntry[K, V]{key: key, hash: h, val: next}) {
+				continue
+			}
+			t.used.Inc()
+			m.len.Inc()
+			return nil, true, true
+		case e == t.movedEmpty:
+			return nil, false, false
+		case e.hash != h || e.key != key:
+			i, n = (i+1)&t.mask, n+1
+			continue
+		case e.moved:
+			// Make sure the frozen value is in the next table before
+			// writing over it there.
+			m.copyIn(t.next.Load(), e)
+			return nil, false, false
+		}
+
+		next, write := fn(e.val)
+		if !write || (next == nil && e.val == nil) {
+			return e.val, false, true
+		}
+		if !slot.CompareAndSwap(e, &hashEntry[K, V]{key: key, hash: h, val: next}) {
+			continue
+		}
+		switch {
+		case e.val == nil:
+			m.len.Inc()
+		case next == nil:
+			m.len.Dec()
+		}
+		return e.val, true, true
+	}
+
+	// Every slot holds a key.
+	m.grow(t)
+	return nil, false, false
+}
+
+// grow starts copying t into a larger table, if that hasn't started yet,
+// and helps with the copy.
+func (m *HashMap[K, V]) grow(t *hashTable[K, V]) {
+	next := t.next.Load()
+	if next == nil {
+		// Leave room for the live entries to double. If most of t is
+		// tombstones, this just cleans them up.
+		capacity := len(t.slots)
+		for live := int(m.len.Load()); capacity/4 < live; {
+			capacity *= 2
+		}
+		t.next.CompareAndSwap(nil, newHashTable[K, V](capacity))
+		next = t.next.Load()
+	}
+	m.helpCopy(t, next)
+}
+
+// helpCopy copies the next unclaimed chunk of t into next.
+func (m *HashMap[K, V]) helpCopy(t, next *hashTable[K, V]) {
+	size := int64(len(t.slots))
+	start := t.claimed.Add(_hashMapCopyChunk) - _hashMapCopyChunk
+	if start >= size {
+		return
+	}
+	end := start + _hashMapCopyChunk
+	if end > size {
+		end = size
+	}
+	var n int64
+	for i := start; i < end; i++ {
+		if m.copySlot(t, next, int(i)) {
+			n++
+		}
+	}
+	m.addCopied(t, n)
+}
+
+// finishCopy copies every slot of t that hasn't been copied yet.
+func (m *HashMap[K, V]) finishCopy(t, next *hashTable[K, V]) {
+	var n int64
+	for i := range t.slots {
+		if m.copySlot(t, next, i) {
+			n++
+		}
+	}
+	m.addCopied(t, n)
+}
+
+// addCopied records that n more slots of t were frozen, and retires t once
+// they all are.
+func (m *HashMap[K, V]) addCopied(t *hashTable[K, V], n int64) {
+	if n > 0 && t.copied.Add(n) == int64(len(t.slots)) {
+		m.promote()
+	}
+}
+
+// promote retires fully copied tables from the root.
+func (m *HashMap[K, V]) promote() {
+	for {
+		t := m.root.Load()
+		next := t.next.Load()
+		if next == nil || t.copied.Load() < int64(len(t.slots)) {
+			return
+		}
+		m.root.CompareAndSwap(t, next)
+	}
+}
+
+// copySlot freezes slot i of t and copies its value into next. It reports
+// whether this call froze the slot.
+func (m *HashMap[K, V]) copySlot(t, next *hashTable[K, V], i int) bool {
+	slot := &t.slots[i]
+	for {
+		e := slot.Load()
+		switch {
+		case e == nil:
+			if slot.CompareAndSwap(nil, t.movedEmpty) {
+				return true
+			}
+		case e.moved:
+			return false
+		default:
+			frozen := &hashEntry[K, V]{key: e.key, hash: e.hash, val: e.val, moved: true}
+			if slot.CompareAndSwap(e, frozen) {
+				m.copyIn(next, frozen)
+				return true
+			}
+		}
+	}
+}
+
+// copyIn inserts the value of a frozen entry into t, unless t already has
+// an entry for its key: that entry is either the same copy or newer.
+func (m *HashMap[K, V]) copyIn(t *hashTable[K, V], e *hashEntry[K, V]) {
+	if e.val == nil {
+		return
+	}
+	for i, n := e.hash&t.mask, 0; n < len(t.slots); {
+		slot := &t.slots[i]
+		cur := slot.Load()
+		switch {
+		case cur == nil:
+			if slot.CompareAndSwap(nil, &hashEntry[K, V]{key: e.key, hash: e.hash, val: e.val}) {
+				t.used.Inc()
+				return
+			}
+			continue
+		case cur == t.movedEmpty:
+			m.copyIn(t.next.Load(), e)
+			return
+		case cur.hash == e.hash && cur.key == e.key:
+			return
+		}
+		i, n = (i+1)&t.mask, n+1
+	}
+	m.grow(t)
+	m.copyIn(t.next.Load(), e)
+}
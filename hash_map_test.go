@@ -0,0 +1,260 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashMap(t *testing.T) {
+	m := NewHashMap[uint64, string](splitmix64)
+	_, ok := m.Load(1)
+	assert.False(t, ok, "Load of a missing key should report false.")
+
+	m.Store(1, "foo")
+	v, ok := m.Load(1)
+	require.True(t, ok, "Load of a stored key should report true.")
+	assert.Equal(t, "foo", v, "Load returned the wrong value.")
+
+	actual, loaded := m.LoadOrStore(1, "bar")
+	assert.True(t, loaded, "LoadOrStore of an existing key should report loaded.")
+	assert.Equal(t, "foo", actual, "LoadOrStore returned the wrong value.")
+	actual, loaded = m.LoadOrStore(2, "bar")
+	assert.False(t, loaded, "LoadOrStore of a new key shouldn't report loaded.")
+	assert.Equal(t, "bar", actual, "LoadOrStore returned the wrong value.")
+
+	assert.False(t, m.CompareAndSwap(1, "bar", "baz"), "CompareAndSwap of the wrong value reported a swap.")
+	assert.True(t, m.CompareAndSwap(1, "foo", "baz"), "CompareAndSwap didn't report a swap.")
+	assert.False(t, m.CompareAndSwap(3, "", "baz"), "CompareAndSwap of a missing key reported a swap.")
+	v, _ = m.Load(1)
+	assert.Equal(t, "baz", v, "CompareAndSwap didn't store the new value.")
+
+	assert.False(t, m.CompareAndDelete(1, "foo"), "CompareAndDelete of the wrong value reported a delete.")
+	assert.True(t, m.CompareAndDelete(1, "baz"), "CompareAndDelete didn't report a delete.")
+	_, ok = m.Load(1)
+	assert.False(t, ok, "CompareAndDelete didn't remove the key.")
+
+	v, loaded = m.LoadAndDelete(2)
+	assert.True(t, loaded && v == "bar", "LoadAndDelete returned %q, %v.", v, loaded)
+	_, loaded = m.LoadAndDelete(2)
+	assert.False(t, loaded, "LoadAndDelete of a missing key should report false.")
+	assert.Equal(t, 0, m.Len(), "Map should be empty.")
+
+	t.Run("resize", func(t *testing.T) {
+		const n = 10000
+		m := NewHashMap[uint64, uint64](splitmix64)
+		for i := uint64(0); i < n; i++ {
+			m.Store(i, i*2)
+		}
+		for i := uint64(0); i < n; i += 2 {
+			m.Delete(i)
+		}
+		assert.Equal(t, n/2, m.Len(), "Len didn't track stores and deletes.")
+
+		got := make(map[uint64]uint64)
+		m.Range(func(k, v uint64) bool {
+			_, dup := got[k]
+			assert.False(t, dup, "Range visited %d twice.", k)
+			got[k] = v
+			return true
+		})
+		assert.Len(t, got, n/2, "Range didn't visit every key.")
+		for i := uint64(0); i < n; i++ {
+			v, ok := m.Load(i)
+			if assert.Equal(t, i%2 == 1, ok, "Load reported the wrong presence for %d.", i) && ok {
+				assert.Equal(t, i*2, v, "Load returned the wrong value for %d.", i)
+			}
+		}
+	})
+
+	t.Run("tombstones", func(t *testing.T) {
+		// Churning through keys fills the table with tombstones, which
+		// resizes must clean up without growing forever.
+		m := NewHashMap[uint64, int](splitmix64)
+		for i := uint64(0); i < 10000; i++ {
+			m.Store(i, 0)
+			m.Delete(i)
+		}
+		assert.Equal(t, 0, m.Len(), "Map should be empty.")
+		assert.Len(t, m.root.Load().slots, _hashMapMinCapacity, "Table grew without live entries.")
+	})
+
+	t.Run("Range stops", func(t *testing.T) {
+		m := NewHashMap[uint64, int](splitmix64)
+		m.Store(1, 1)
+		m.Store(2, 2)
+		var calls int
+		m.Range(func(uint64, int) bool {
+			calls++
+			return false
+		})
+		assert.Equal(t, 1, calls, "Range didn't stop when fn returned false.")
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		assert.Panics(t, func() { NewHashMap[uint64, int](nil) }, "NewHashMap should panic without a hash.")
+	})
+}
+
+func TestHashMapStress(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 2000
+		counter    = ^uint64(0)
+	)
+
+	m := NewHashMap[uint64, uint64](splitmix64)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				// Each goroutine owns a range of keys, so we know what
+				// must be left once they're done...
+				k := uint64(g*iterations + i)
+				m.Store(k, k)
+				if i%4 == 0 {
+					m.Delete(k)
+				}
+
+				// ...and they all race to increment one shared key while
+				// their stores resize the table underneath.
+				for {
+					cur, _ := m.LoadOrStore(counter, 0)
+					if m.CompareAndSwap(counter, cur, cur+1) {
+						break
+					}
+				}
+
+				if i%100 == 0 {
+					prev := map[uint64]struct{}{}
+					m.Range(func(k, _ uint64) bool {
+						_, dup := prev[k]
+						assert.False(t, dup, "Range visited %d twice.", k)
+						prev[k] = struct{}{}
+						return true
+					})
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	v, _ := m.Load(counter)
+	assert.Equal(t, uint64(goroutines*iterations), v, "Lost concurrent CompareAndSwaps.")
+	assert.Equal(t, goroutines*iterations*3/4+1, m.Len(), "Len didn't match the surviving keys.")
+	for k := uint64(0); k < goroutines*iterations; k++ {
+		v, ok := m.Load(k)
+		if ok != (k%iterations%4 != 0) {
+			t.Errorf("Load(%d) reported %v.", k, ok)
+		} else if ok && v != k {
+			t.Errorf("Load(%d) returned %d.", k, v)
+		}
+	}
+}
+
+// benchmarkMap is the subset of map operations the HashMap benchmarks use.
+type benchmarkMap interface {
+	Load(key uint64) (uint64, bool)
+	Store(key, value uint64)
+}
+
+type syncMap struct{ m sync.Map }
+
+func (m *syncMap) Load(key uint64) (uint64, bool) {
+	v, ok := m.m.Load(key)
+	if !ok {
+		return 0, false
+	}
+	return v.(uint64), true
+}
+
+func (m *syncMap) Store(key, value uint64) { m.m.Store(key, value) }
+
+type rwMutexMap struct {
+	mu sync.RWMutex
+	m  map[uint64]uint64
+}
+
+func (m *rwMutexMap) Load(key uint64) (uint64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.m[key]
+	return v, ok
+}
+
+func (m *rwMutexMap) Store(key, value uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m[key] = value
+}
+
+func BenchmarkHashMap(b *testing.B) {
+	const keys = 1 << 16
+
+	maps := []struct {
+		name string
+		new  func() benchmarkMap
+	}{
+		{"HashMap", func() benchmarkMap { return NewHashMap[uint64, uint64](splitmix64) }},
+		{"sync.Map", func() benchmarkMap { return &syncMap{} }},
+		{"RWMutex", func() benchmarkMap { return &rwMutexMap{m: make(map[uint64]uint64)} }},
+	}
+	workloads := []struct {
+		name       string
+		writeEvery uint64 // one in writeEvery operations is a Store
+	}{
+		{"read-mostly", 10},
+		{"write-heavy", 2},
+	}
+
+	for _, w := range workloads {
+		for _, mm := range maps {
+			b.Run(w.name+"/"+mm.name, func(b *testing.B) {
+				m := mm.new()
+				for i := uint64(0); i < keys; i++ {
+					m.Store(i, i)
+				}
+				var seed Uint64
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					x := splitmix64(seed.Inc())
+					for pb.Next() {
+						x = splitmix64(x)
+						if k := x % keys; x>>32%w.writeEvery == 0 {
+							m.Store(k, x)
+						} else {
+							m.Load(k)
+						}
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	// MinHyperLogLogPrecision is the smallest precision accepted by
+	// NewHyperLogLog.
+	MinHyperLogLogPrecision = 4
+
+	// MaxHyperLogLogPrecision is the largest precision accepted by
+	// NewHyperLogLog.
+	MaxHyperLogLogPrecision = 18
+
+	// Each register is stored in an 8-bit lane of a 64-bit word.
+	_hllLaneBits     = 8
+	_hllLanesPerWord = 64 / _hllLaneBits
+	_hllLaneMask     = 1<<_hllLaneBits - 1
+
+	// Version of the format produced by HyperLogLog.MarshalBinary.
+	_hllEncodingVersion = 1
+)
+
+// HyperLogLog estimates the number of distinct values added to it.
+//
+// A HyperLogLog with precision p keeps 2^p registers and estimates
+// cardinality with a relative standard error of about 1.04/sqrt(2^p).
+// Registers are packed into Uint64 words and updated with an atomic maximum,
+// so Add is lock-free and may be called concurrently with every other method.
+//
+// Callers are expected to hash their values with a good 64-bit hash function
+// before adding them.
+//
+// The zero value is not usable; create HyperLogLogs with NewHyperLogLog.
+type HyperLogLog struct {
+	_ nocmp // disallow non-atomic comparison
+
+	precision uint8
+	words     []Uint64
+}
+
+// NewHyperLogLog creates a HyperLogLog with 2^precision registers.
+//
+// NewHyperLogLog panics if precision is outside the range
+// [MinHyperLogLogPrecision, MaxHyperLogLogPrecision].
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+	if precision < MinHyperLogLogPrecision || precision > MaxHyperLogLogPrecision {
+		panic(fmt.Sprintf("atomic: HyperLogLog precision must be in [%d, %d], got %d",
+			MinHyperLogLogPrecision, MaxHyperLogLogPrecision, precision))
+	}
+	return &HyperLogLog{
+		precision: precision,
+		words:     make([]Uint64, (1<<precision)/_hllLanesPerWord),
+	}
+}
+
+// Precision reports the precision the HyperLogLog was created with.
+func (h *HyperLogLog) Precision() uint8 {
+	return h.precision
+}
+
+// Add atomically records a value with the given 64-bit hash.
+func (h *HyperLogLog) Add(hash uint64) {
+	p := uint(h.precision)
+	idx := hash >> (64 - p)
+	// Set a sentinel bit so that the rank is bounded even if the remaining
+	// bits are all zero.
+	rank := uint64(bits.LeadingZeros64(hash<<p|1<<(p-1))) + 1
+	h.maxRegister(idx, rank)
+}
+
+// maxRegister atomically raises register idx to val if it is smaller.
+func (h *HyperLogLog) maxRegister(idx, val uint64) {
+	word := &h.words[idx/_hllLanesPerWord]
+	shift := (idx % _hllLanesPerWord) * _hllLaneBits
+	for {
+		old := word.Load()
+		if (old>>shift)&_hllLaneMask >= val {
+			return
+		}
+		new := old&^(_hllLaneMask<<shift) | val<<shift
+		if word.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+func (h *HyperLogLog) register(idx uint64) uint64 {
+	shift := (idx % _hllLanesPerWord) * _hllLaneBits
+	return (h.words[idx/_hllLanesPerWord].Load() >> shift) & _hllLaneMask
+}
+
+// Estimate reports the estimated number of distinct values added.
+//
+// Estimate reads each register atomically, but not all registers at once, so
+// values added concurrently may or may not be reflected in the result.
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(uint64(1) << h.precision)
+
+	var (
+		sum   float64
+		zeros int
+	)
+	for i := uint64(0); i < uint64(len(h.words))*_hllLanesPerWord; i++ {
+		r := h.register(i)
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	est := hllAlpha(m) * m * m / sum
+	if est <= 2.5*m && zeros > 0 {
+		// Small range correction: fall back to linear counting.
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
+
+func hllAlpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
+
+// Merge atomically folds the registers of other into h, so that h estimates
+// the cardinality of the union of both sets. The two sketches must have the
+// same precision.
+//
+// Merge may run concurrently with Add on either sketch.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return fmt.Errorf("atomic: cannot merge HyperLogLog of precision %d into precision %d",
+			other.precision, h.precision)
+	}
+	for i := range other.words {
+		w := other.words[i].Load()
+		for lane := uint64(0); lane < _hllLanesPerWord; lane++ {
+			if r := (w >> (lane * _hllLaneBits)) & _hllLaneMask; r > 0 {
+				h.maxRegister(uint64(i)*_hllLanesPerWord+lane, r)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalBinary encodes the HyperLogLog into a binary form.
+//
+// The encoding is a version byte (currently 1), the precision, and one byte
+// per register in register order.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	n := uint64(len(h.words)) * _hllLanesPerWord
+	b := make([]byte, 2, 2+n)
+	b[0] = _hllEncodingVersion
+	b[1] = h.precision
+	for i := uint64(0); i < n; i++ {
+		b = append(b, byte(h.register(i)))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a HyperLogLog produced by MarshalBinary, replacing
+// the receiver's precision and registers.
+//
+// UnmarshalBinary must not be called concurrently with other methods.
+func (h *HyperLogLog) UnmarshalBinary(b []byte) error {
+	if len(b) < 2 {
+		return errors.New("atomic: HyperLogLog encoding is too short")
+	}
+	if b[0] != _hllEncodingVersion {
+		return fmt.Errorf("atomic: unsupported HyperLogLog encoding version %d", b[0])
+	}
+	precision := b[1]
+	if precision < MinHyperLogLogPrecision || precision > MaxHyperLogLogPrecision {
+		return fmt.Errorf("atomic: invalid HyperLogLog precision %d", precision)
+	}
+	regs := b[2:]
+	if len(regs) != 1<<precision {
+		return fmt.Errorf("atomic: HyperLogLog of precision %d needs %d registers, got %d",
+			precision, 1<<precision, len(regs))
+	}
+
+	words := make([]Uint64, len(regs)/_hllLanesPerWord)
+	for i, r := range regs {
+		if r > 64-precision+1 {
+			return fmt.Errorf("atomic: invalid HyperLogLog register value %d", r)
+		}
+		words[i/_hllLanesPerWord].v |= uint64(r) << (uint(i%_hllLanesPerWord) * _hllLaneBits)
+	}
+
+	h.precision = precision
+	h.words = words
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertWithinRelative checks that got is within tolerance of want, relative
+// to want.
+func assertWithinRelative(t *testing.T, want, got uint64, tolerance float64) bool {
+	t.Helper()
+
+	return assert.InDeltaf(t, float64(want), float64(got), tolerance*float64(want),
+		"estimate %v is too far from %v", got, want)
+}
+
+func TestHyperLogLog(t *testing.T) {
+	for _, precision := range []uint8{MinHyperLogLogPrecision, 10, 14} {
+		h := NewHyperLogLog(precision)
+		require.Equal(t, precision, h.Precision(), "Precision didn't match.")
+		require.Equal(t, uint64(0), h.Estimate(), "Empty sketch should estimate zero.")
+
+		stderr := 1.04 / math.Sqrt(float64(uint64(1)<<precision))
+		for _, n := range []uint64{10, 1000, 100000} {
+			h := NewHyperLogLog(precision)
+			for i := uint64(0); i < n; i++ {
+				h.Add(splitmix64(i))
+				// Duplicates must not affect the estimate.
+				h.Add(splitmix64(i))
+			}
+			assertWithinRelative(t, n, h.Estimate(), 4*stderr)
+		}
+	}
+}
+
+func TestHyperLogLogConcurrentAdd(t *testing.T) {
+	const (
+		goroutines = 8
+		perRoutine = 20000
+	)
+
+	h := NewHyperLogLog(14)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			// Half of each goroutine's values overlap with its neighbour.
+			base := uint64(g) * perRoutine / 2
+			for i := uint64(0); i < perRoutine; i++ {
+				h.Add(splitmix64(base + i))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	want := uint64((goroutines + 1) * perRoutine / 2)
+	assertWithinRelative(t, want, h.Estimate(), 0.05)
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a := NewHyperLogLog(12)
+	b := NewHyperLogLog(12)
+	for i := uint64(0); i < 30000; i++ {
+		a.Add(splitmix64(i))
+		b.Add(splitmix64(i + 15000))
+	}
+
+	require.NoError(t, a.Merge(b), "Merge failed.")
+	assertWithinRelative(t, 45000, a.Estimate(), 0.1)
+
+	err := a.Merge(NewHyperLogLog(10))
+	assert.Error(t, err, "Merge of mismatched precision should fail.")
+}
+
+func TestHyperLogLogBinary(t *testing.T) {
+	h := NewHyperLogLog(8)
+	for i := uint64(0); i < 5000; i++ {
+		h.Add(splitmix64(i))
+	}
+
+	b, err := h.MarshalBinary()
+	require.NoError(t, err, "MarshalBinary failed.")
+	require.Len(t, b, 2+256, "Unexpected encoding length.")
+
+	var got HyperLogLog
+	require.NoError(t, got.UnmarshalBinary(b), "UnmarshalBinary failed.")
+	assert.Equal(t, h.Precision(), got.Precision(), "Precision wasn't restored.")
+	assert.Equal(t, h.Estimate(), got.Estimate(), "Estimate wasn't restored.")
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			desc string
+			give []byte
+		}{
+			{desc: "empty", give: nil},
+			{desc: "bad version", give: []byte{2, 4}},
+			{desc: "bad precision", give: []byte{1, 2}},
+			{desc: "truncated", give: append([]byte{1, 4}, make([]byte, 15)...)},
+			{desc: "bad register", give: append([]byte{1, 4, 62}, make([]byte, 15)...)},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.desc, func(t *testing.T) {
+				var h HyperLogLog
+				assert.Error(t, h.UnmarshalBinary(tt.give))
+			})
+		}
+	})
+}
+
+func TestNewHyperLogLogPanics(t *testing.T) {
+	assert.Panics(t, func() { NewHyperLogLog(MinHyperLogLogPrecision - 1) })
+	assert.Panics(t, func() { NewHyperLogLog(MaxHyperLogLogPrecision + 1) })
+}
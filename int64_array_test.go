@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInt64Array(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var a Int64Array
+		assert.Equal(t, 0, a.Len(), "Zero value should be empty.")
+		assert.Empty(t, a.Snapshot(), "Zero value should be empty.")
+		a.Reset()
+	})
+
+	for _, opts := range [][]ArrayOption{nil, {WithCacheLinePadding()}} {
+		a := NewInt64Array(4, opts...)
+		require.Equal(t, 4, a.Len(), "Len didn't match.")
+
+		a.Store(0, 42)
+		assert.Equal(t, int64(42), a.Load(0), "Store didn't set the correct value.")
+		assert.Equal(t, int64(40), a.Add(0, -2), "Add didn't work.")
+		assert.Equal(t, int64(40), a.Swap(0, 1), "Swap didn't return the old value.")
+		assert.False(t, a.CompareAndSwap(0, 2, 3), "CAS of the wrong value reported a swap.")
+		assert.True(t, a.CompareAndSwap(0, 1, 3), "CAS didn't report a swap.")
+
+		a.AddVector([]int64{1, 2, 0, -4})
+		assert.Equal(t, []int64{4, 2, 0, -4}, a.Snapshot(), "AddVector didn't add to every element.")
+		assert.Panics(t, func() { a.AddVector([]int64{1}) }, "AddVector of the wrong length should panic.")
+
+		a.Reset()
+		assert.Equal(t, []int64{0, 0, 0, 0}, a.Snapshot(), "Reset didn't zero every element.")
+	}
+
+	t.Run("padding", func(t *testing.T) {
+		a := NewInt64Array(2, WithCacheLinePadding())
+		gap := uintptr(unsafe.Pointer(a.at(1))) - uintptr(unsafe.Pointer(a.at(0)))
+		assert.Equal(t, uintptr(_cacheLineSize), gap, "Padded elements should be a cache line apart.")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		a := NewInt64Array(3)
+		a.Store(1, -1)
+		bytes, err := json.Marshal(a)
+		require.NoError(t, err, "json.Marshal errored unexpectedly.")
+		assert.Equal(t, "[0,-1,0]", string(bytes), "json.Marshal encoded the wrong bytes.")
+
+		require.NoError(t, json.Unmarshal([]byte("[1,2,3]"), a), "json.Unmarshal errored unexpectedly.")
+		assert.Equal(t, []int64{1, 2, 3}, a.Snapshot(), "json.Unmarshal didn't set the correct values.")
+		assert.Error(t, json.Unmarshal([]byte("[1]"), a), "json.Unmarshal of the wrong length should fail.")
+		err = json.Unmarshal([]byte(`["1","2","3"]`), a)
+		assertErrorJSONUnmarshalType(t, err,
+			"json.Unmarshal failed with unexpected error %v, want UnmarshalTypeError.", err)
+
+		var empty Int64Array
+		require.NoError(t, json.Unmarshal([]byte("[4,5]"), &empty), "json.Unmarshal errored unexpectedly.")
+		assert.Equal(t, []int64{4, 5}, empty.Snapshot(), "json.Unmarshal didn't size an empty array.")
+
+		padded := NewInt64Array(0, WithCacheLinePadding())
+		require.NoError(t, json.Unmarshal([]byte("[6,7]"), padded), "json.Unmarshal errored unexpectedly.")
+		assert.Equal(t, []int64{6, 7}, padded.Snapshot(), "json.Unmarshal didn't size an empty array.")
+		gap := uintptr(unsafe.Pointer(padded.at(1))) - uintptr(unsafe.Pointer(padded.at(0)))
+		assert.Equal(t, uintptr(_cacheLineSize), gap, "json.Unmarshal dropped the padding of an empty array.")
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		assert.Panics(t, func() { NewInt64Array(-1) }, "Negative lengths should panic.")
+	})
+}
+
+func TestInt64ArrayConcurrentAdd(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 1000
+	)
+
+	a := NewInt64Array(3, WithCacheLinePadding())
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				a.AddVector([]int64{1, -1, 2})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int64{goroutines * iterations, -goroutines * iterations, 2 * goroutines * iterations},
+		a.Snapshot(), "Lost concurrent updates.")
+}
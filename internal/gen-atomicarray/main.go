@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// gen-atomicarray generates a fixed-length array of atomic values.
+//
+//	gen-atomicarray -name Int64Array -elem Int64 -type int64 -file out.go
+//
+// The generated array stores its elements as the atomic type named by -elem,
+// which must provide Load, Store, Add, Swap, and CompareAndSwap methods for
+// values of the type named by -type.
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+func main() {
+	log.SetFlags(0)
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func run(args []string) error {
+	var opts struct {
+		Name  string
+		Elem  string
+		Type  string
+		File  string
+		Float bool
+	}
+
+	flag := flag.NewFlagSet("gen-atomicarray", flag.ContinueOnError)
+
+	flag.StringVar(&opts.Name, "name", "", "name of the generated type (e.g. Int64Array)")
+	flag.StringVar(&opts.Elem, "elem", "", "name of the atomic element type (e.g. Int64)")
+	flag.StringVar(&opts.Type, "type", "", "name of the element value type (e.g. int64)")
+	flag.StringVar(&opts.File, "file", "", "output file path (default: stdout)")
+	flag.BoolVar(&opts.Float, "float", false, "whether the type is a floating point type")
+
+	if err := flag.Parse(args); err != nil {
+		return err
+	}
+
+	if len(opts.Name) == 0 || len(opts.Elem) == 0 || len(opts.Type) == 0 {
+		return errors.New("flags -name, -elem, and -type are required")
+	}
+
+	var w io.Writer = os.Stdout
+	if file := opts.File; len(file) > 0 {
+		f, err := os.Create(file)
+		if err != nil {
+			return fmt.Errorf("create %q: %v", file, err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	article := "a"
+	if strings.IndexByte("AEIO", opts.Name[0]) >= 0 {
+		article = "an"
+	}
+
+	data := struct {
+		Name    string
+		Article string
+		Elem    string
+		Type    string
+		Float   bool
+		ToYear  int
+	}{
+		Name:    opts.Name,
+		Article: article,
+		Elem:    opts.Elem,
+		Type:    opts.Type,
+		Float:   opts.Float,
+		ToYear:  time.Now().Year(),
+	}
+
+	var buff bytes.Buffer
+	if err := _tmpl.ExecuteTemplate(&buff, "array.tmpl", data); err != nil {
+		return fmt.Errorf("render template: %v", err)
+	}
+
+	bs, err := format.Source(buff.Bytes())
+	if err != nil {
+		return fmt.Errorf("reformat source: %v", err)
+	}
+
+	io.WriteString(w, "// @generated Code generated by gen-atomicarray.\n\n")
+	_, err = w.Write(bs)
+	return err
+}
+
+var (
+	//go:embed *.tmpl
+	_tmplFS embed.FS
+
+	_tmpl = template.Must(template.New("atomicarray").ParseFS(_tmplFS, "*.tmpl"))
+)
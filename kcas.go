@@ -0,0 +1,271 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sort"
+	"unsafe"
+)
+
+// KCASVar is a value that can take part in KCAS, a compare-and-swap over
+// several words at once. Only KCASVars can take part in KCAS; they can't
+// share storage with Int64, Pointer, and the other types in this package.
+//
+// The zero value holds the zero value of T.
+type KCASVar[T comparable] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	id Uint64 // orders acquisition in KCAS; assigned on first use
+	v  Pointer[kcasWord[T]]
+
+	// Round the size up to a multiple of 8 bytes so that id stays 8-byte
+	// aligned in arrays on 32-bit platforms.
+	_ [(8 - unsafe.Sizeof(uintptr(0))%8) % 8]byte
+}
+
+// kcasWord is the immutable content of a KCASVar. While a KCAS holds the
+// word, desc points to its descriptor, and the logical value is new if the
+// KCAS succeeded and val, the expected old value, otherwise. Words are
+// replaced on every change, so comparing them by address can't suffer from
+// ABA.
+type kcasWord[T comparable] struct {
+	val  T
+	new  T
+	desc *kcasDescriptor
+}
+
+// KCASEntry is one word of a KCAS operation. Create entries with
+// KCASVar.Expect.
+type KCASEntry interface {
+	kcasID() uint64
+	acquire(d *kcasDescriptor) bool
+	release(d *kcasDescriptor, succeeded bool)
+}
+
+// Status of a KCAS descriptor.
+const (
+	kcasUndecided int32 = iota
+	kcasSucceeded
+	kcasFailed
+)
+
+type kcasDescriptor struct {
+	status  Int32
+	entries []KCASEntry // sorted by kcasID
+}
+
+var _kcasNextID Uint64
+
+// KCAS atomically performs a compare-and-swap on several KCASVars: if every
+// variable holds the old value of its entry, KCAS sets them all to their
+// new values and returns true. Otherwise it changes nothing and returns
+// false.
+//
+// KCAS doesn't take locks. It records the operation in a descriptor and
+// installs it in each variable, in a global order, so that goroutines that
+// run into it, including Load, can help it complete instead of waiting.
+// Every call allocates, so prefer a single CompareAndSwap when only one
+// variable changes.
+//
+// KCAS panics if two entries refer to the same variable.
+func KCAS(entries ...KCASEntry) bool {
+	if len(entries) == 0 {
+		return true
+	}
+
+	sorted := make([]KCASEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].kcasID() < sorted[j].kcasID()
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].kcasID() == sorted[i].kcasID() {
+			panic("atomic: KCAS given the same KCASVar more than once")
+		}
+	}
+
+	d := &kcasDescriptor{entries: sorted}
+	return d.help()
+}
+
+// NewKCASVar creates a new KCASVar holding val.
+func NewKCASVar[T comparable](val T) *KCASVar[T] {
+	var v KCASVar[T]
+	v.Store(val)
+	return &v
+}
+
+// Load atomically loads the value. If a KCAS on v is in progress, Load
+// helps it complete first.
+func (v *KCASVar[T]) Load() T {
+	for {
+		cur := v.v.Load()
+		if val, ok := v.value(cur); ok {
+			return val
+		}
+	}
+}
+
+// Store atomically stores val, after helping any KCAS in progress on v.
+func (v *KCASVar[T]) Store(val T) {
+	next := &kcasWord[T]{val: val}
+	for {
+		cur := v.v.Load()
+		if _, ok := v.value(cur); ok && v.v.CompareAndSwap(cur, next) {
+			return
+		}
+	}
+}
+
+// CompareAndSwap atomically replaces the value with new if it is old, after
+// helping any KCAS in progress on v. It reports whether it did.
+func (v *KCASVar[T]) CompareAndSwap(old, new T) (swapped bool) {
+	var next *kcasWord[T]
+	for {
+		cur := v.v.Load()
+		val, ok := v.value(cur)
+		if !ok {
+			continue
+		}
+		if val != old {
+			return false
+		}
+		if next == nil {
+			next = &kcasWord[T]{val: new}
+		}
+		if v.v.CompareAndSwap(cur, next) {
+			return true
+		}
+	}
+}
+
+// value returns the logical value held in cur. If a KCAS on cur is still
+// undecided, value helps it complete and returns false: the caller should
+// load the word again.
+func (v *KCASVar[T]) value(cur *kcasWord[T]) (val T, ok bool) {
+	if cur == nil {
+		return val, true
+	}
+	if cur.desc == nil {
+		return cur.val, true
+	}
+	switch cur.desc.status.Load() {
+	case kcasUndecided:
+		cur.desc.help()
+		return val, false
+	case kcasSucceeded:
+		return cur.new, true
+	default:
+		return cur.val, true
+	}
+}
+
+// Expect returns a KCAS entry that requires v to hold old and sets it to
+// new.
+func (v *KCASVar[T]) Expect(old, new T) KCASEntry {
+	return &kcasEntry[T]{v: v, old: old, new: new}
+}
+
+func (v *KCASVar[T]) kcasID() uint64 {
+	for {
+		if id := v.id.Load(); id != 0 {
+			return id
+		}
+		v.id.CompareAndSwap(0, _kcasNextID.Inc())
+	}
+}
+
+type kcasEntry[T comparable] struct {
+	v        *KCASVar[T]
+	old, new T
+}
+
+func (e *kcasEntry[T]) kcasID() uint64 { return e.v.kcasID() }
+
+// acquire installs d in the word if it holds the expected value, helping
+// any other KCAS in the way. It reports false if the word holds some other
+// value.
+func (e *kcasEntry[T]) acquire(d *kcasDescriptor) bool {
+	var next *kcasWord[T]
+	for {
+		cur := e.v.v.Load()
+		if cur != nil && cur.desc == d {
+			return true
+		}
+		if cur != nil && cur.desc != nil && cur.desc.status.Load() == kcasUndecided {
+			cur.desc.help()
+			continue
+		}
+		// Once d is decided, installing it could only be stale. The
+		// compare-and-swap below can't succeed if the word changed since
+		// this check, so d is never installed after it succeeded.
+		if d.status.Load() != kcasUndecided {
+			return true
+		}
+		val, _ := e.v.value(cur)
+		if val != e.old {
+			return false
+		}
+		if next == nil {
+			next = &kcasWord[T]{val: e.old, new: e.new, desc: d}
+		}
+		if e.v.v.CompareAndSwap(cur, next) {
+			return true
+		}
+	}
+}
+
+// release replaces d in the word with the value it decided on.
+func (e *kcasEntry[T]) release(d *kcasDescriptor, succeeded bool) {
+	cur := e.v.v.Load()
+	if cur == nil || cur.desc != d {
+		return
+	}
+	val := e.old
+	if succeeded {
+		val = e.new
+	}
+	// If this fails, another goroutine released the word already.
+	e.v.v.CompareAndSwap(cur, &kcasWord[T]{val: val})
+}
+
+// help drives d to completion and reports whether it succeeded.
+func (d *kcasDescriptor) help() bool {
+	if d.status.Load() == kcasUndecided {
+		outcome := kcasSucceeded
+		for _, e := range d.entries {
+			if !e.acquire(d) {
+				outcome = kcasFailed
+				break
+			}
+		}
+		d.status.CompareAndSwap(kcasUndecided, outcome)
+	}
+
+	succeeded := d.status.Load() == kcasSucceeded
+	for _, e := range d.entries {
+		e.release(d, succeeded)
+	}
+	return succeeded
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKCASVar(t *testing.T) {
+	var zero KCASVar[int]
+	assert.Equal(t, 0, zero.Load(), "Zero value should hold 0.")
+
+	v := NewKCASVar(1)
+	assert.Equal(t, 1, v.Load(), "NewKCASVar didn't store the value.")
+
+	v.Store(2)
+	assert.Equal(t, 2, v.Load(), "Store didn't set the value.")
+
+	assert.False(t, v.CompareAndSwap(1, 3), "CompareAndSwap succeeded with the wrong old value.")
+	assert.True(t, v.CompareAndSwap(2, 3), "CompareAndSwap failed.")
+	assert.Equal(t, 3, v.Load(), "CompareAndSwap didn't set the value.")
+}
+
+func TestKCAS(t *testing.T) {
+	var (
+		a KCASVar[int64]
+		b KCASVar[string]
+		c KCASVar[*int]
+	)
+	x := new(int)
+
+	assert.True(t, KCAS(), "KCAS without entries failed.")
+
+	require.True(t, KCAS(
+		a.Expect(0, 10),
+		b.Expect("", "foo"),
+		c.Expect(nil, x),
+	), "KCAS failed.")
+	assert.Equal(t, int64(10), a.Load(), "KCAS didn't set a.")
+	assert.Equal(t, "foo", b.Load(), "KCAS didn't set b.")
+	assert.True(t, c.Load() == x, "KCAS didn't set c.")
+
+	assert.False(t, KCAS(
+		a.Expect(10, 20),
+		b.Expect("bar", "baz"),
+		c.Expect(x, nil),
+	), "KCAS succeeded with a wrong old value.")
+	assert.Equal(t, int64(10), a.Load(), "Failed KCAS changed a.")
+	assert.Equal(t, "foo", b.Load(), "Failed KCAS changed b.")
+	assert.True(t, c.Load() == x, "Failed KCAS changed c.")
+
+	// Single-word operations work on variables that took part in a KCAS.
+	assert.True(t, a.CompareAndSwap(10, 11), "CompareAndSwap after KCAS failed.")
+	assert.True(t, KCAS(b.Expect("foo", "bar"), a.Expect(11, 12)), "KCAS in a different order failed.")
+	assert.Equal(t, int64(12), a.Load(), "KCAS didn't set a.")
+
+	assert.PanicsWithValue(t, "atomic: KCAS given the same KCASVar more than once", func() {
+		KCAS(a.Expect(12, 13), b.Expect("bar", "baz"), a.Expect(12, 14))
+	}, "KCAS should panic on duplicate variables.")
+	assert.Equal(t, int64(12), a.Load(), "Rejected KCAS changed a.")
+}
+
+func TestKCASConcurrent(t *testing.T) {
+	const (
+		accounts   = 4
+		initial    = 100
+		goroutines = 8
+		iterations = 500
+	)
+
+	var balances [accounts]KCASVar[int]
+	for i := range balances {
+		balances[i].Store(initial)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		done      Bool
+		snapshots Int64
+	)
+
+	// Move money between accounts, keeping the total constant.
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				from := &balances[(g+i)%accounts]
+				to := &balances[(g+2*i+1)%accounts]
+				if from == to {
+					continue
+				}
+				for {
+					fb, tb := from.Load(), to.Load()
+					if KCAS(from.Expect(fb, fb-1), to.Expect(tb, tb+1)) {
+						break
+					}
+				}
+			}
+		}(g)
+	}
+
+	// Take consistent snapshots: a KCAS that leaves every balance unchanged
+	// succeeds only if the balances loaded were current at the same time.
+	var reader sync.WaitGroup
+	reader.Add(1)
+	go func() {
+		defer reader.Done()
+		entries := make([]KCASEntry, accounts)
+		for !done.Load() {
+			var sum int
+			for i := range balances {
+				b := balances[i].Load()
+				sum += b
+				entries[i] = balances[i].Expect(b, b)
+			}
+			if KCAS(entries...) {
+				snapshots.Inc()
+				assert.Equal(t, accounts*initial, sum, "Snapshot saw an inconsistent total.")
+			}
+		}
+	}()
+
+	wg.Wait()
+	done.Store(true)
+	reader.Wait()
+
+	var sum int
+	for i := range balances {
+		sum += balances[i].Load()
+	}
+	assert.Equal(t, accounts*initial, sum, "Transfers didn't preserve the total.")
+	t.Logf("took %v consistent snapshots", snapshots.Load())
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"runtime"
+	"sync"
+)
+
+// LeftRight gives wait-free reads of a mutable structure, such as a large
+// index, without copying it on each write. It keeps two instances of the
+// structure: readers use the active one while writers modify the standby.
+//
+// Write applies an operation to the standby instance and records it in a
+// log. Publish atomically makes the standby active, waits for readers
+// still using the old instance to finish, and replays the log onto it so
+// that both instances are identical again. Operations are therefore
+// applied twice, once to each instance, and must be deterministic.
+//
+// Readers register a LeftRightReader and read through it. Each reader has
+// its own epoch counter, which is odd while it's reading, so readers never
+// contend with each other; Publish waits only for readers whose epoch was
+// odd when it switched instances.
+//
+// The zero value is not usable; create LeftRights with NewLeftRight.
+type LeftRight[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	active  Pointer[T]
+	readers COWSlice[*LeftRightReader[T]]
+
+	mu        sync.Mutex // serializes writers
+	instances [2]*T
+	log       []func(*T)
+}
+
+// LeftRightReader reads from a LeftRight. Each reader must be used by one
+// goroutine at a time.
+type LeftRightReader[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	// epoch comes right after a full cache line of padding so that it's
+	// 8-byte aligned on 32-bit platforms.
+	_     cacheLinePad
+	epoch Int64 // odd while reading
+	_     cacheLinePad
+
+	lr *LeftRight[T]
+}
+
+// NewLeftRight creates a LeftRight from two identical instances of the
+// structure, with left active. It panics if either is nil or both are the
+// same instance.
+func NewLeftRight[T any](left, right *T) *LeftRight[T] {
+	if left == nil || right == nil || left == right {
+		panic("atomic: LeftRight requires two distinct instances")
+	}
+	lr := &LeftRight[T]{instances: [2]*T{left, right}}
+	lr.active.Store(left)
+	return lr
+}
+
+// NewReader registers a new reader. Close it when it's no longer needed,
+// since Publish checks every open reader.
+func (lr *LeftRight[T]) NewReader() *LeftRightReader[T] {
+	r := &LeftRightReader[T]{lr: lr}
+	lr.readers.Append(r)
+	return r
+}
+
+// Read calls fn with the active instance, which fn must not modify or
+// retain after it returns. Read is wait-free, but fn must not call Write
+// or Publish, and must not call Read on the same reader.
+func (r *LeftRightReader[T]) Read(fn func(v *T)) {
+	r.epoch.Inc()
+	defer r.epoch.Inc()
+	fn(r.lr.active.Load())
+}
+
+// Close unregisters the reader. It must not be used afterwards.
+func (r *LeftRightReader[T]) Close() {
+	r.lr.readers.RemoveFunc(func(other *LeftRightReader[T]) bool {
+		return other == r
+	})
+}
+
+// Write applies op to the standby instance and records it to be replayed
+// onto the other instance by Publish. Readers don't see the change until
+// Publish is called.
+func (lr *LeftRight[T]) Write(op func(v *T)) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	op(lr.standby())
+	lr.log = append(lr.log, op)
+}
+
+// Publish makes all writes so far visible to readers. It blocks until
+// readers of the previous instance are done with it.
+func (lr *LeftRight[T]) Publish() {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	if len(lr.log) == 0 {
+		return
+	}
+
+	old := lr.active.Load()
+	lr.active.Store(lr.standby())
+
+	// Readers that start after this point see the new instance, so only
+	// readers that are already reading may still hold the old one.
+	for _, r := range lr.readers.Snapshot() {
+		if epoch := r.epoch.Load(); epoch&1 != 0 {
+			for r.epoch.Load() == epoch {
+				runtime.Gosched()
+			}
+		}
+	}
+
+	for _, op := range lr.log {
+		op(old)
+	}
+	for i := range lr.log {
+		lr.log[i] = nil // don't retain closures
+	}
+	lr.log = lr.log[:0]
+}
+
+func (lr *LeftRight[T]) standby() *T {
+	if lr.active.Load() == lr.instances[0] {
+		return lr.instances[1]
+	}
+	return lr.instances[0]
+}
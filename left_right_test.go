@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeftRight(t *testing.T) {
+	lr := NewLeftRight(&map[string]int{}, &map[string]int{})
+	r := lr.NewReader()
+	defer r.Close()
+
+	read := func(key string) (v int, ok bool) {
+		r.Read(func(m *map[string]int) {
+			v, ok = (*m)[key]
+		})
+		return v, ok
+	}
+
+	lr.Write(func(m *map[string]int) { (*m)["foo"] = 1 })
+	_, ok := read("foo")
+	assert.False(t, ok, "Write shouldn't be visible before Publish.")
+
+	lr.Publish()
+	v, ok := read("foo")
+	assert.True(t, ok && v == 1, "Publish didn't make the write visible.")
+
+	lr.Write(func(m *map[string]int) { (*m)["bar"] = 2 })
+	lr.Write(func(m *map[string]int) { delete(*m, "foo") })
+	lr.Publish()
+	lr.Publish() // no-op
+	_, ok = read("foo")
+	assert.False(t, ok, "Publish didn't apply every write.")
+
+	for _, m := range lr.instances {
+		assert.Equal(t, map[string]int{"bar": 2}, *m, "Instances diverged.")
+	}
+
+	t.Run("panics", func(t *testing.T) {
+		m := &map[string]int{}
+		assert.Panics(t, func() { NewLeftRight(m, m) }, "Same instance twice should panic.")
+		assert.Panics(t, func() { NewLeftRight(m, nil) }, "Nil instance should panic.")
+	})
+}
+
+func TestLeftRightConcurrent(t *testing.T) {
+	const (
+		readers    = 4
+		iterations = 500
+	)
+
+	// Every write keeps the invariant len(s) == s[len(s)-1]+1, which a
+	// reader would see broken if a writer touched the instance it's using.
+	lr := NewLeftRight(&[]int{}, &[]int{})
+
+	var (
+		done Bool
+		wg   sync.WaitGroup
+	)
+	wg.Add(readers)
+	for i := 0; i < readers; i++ {
+		go func() {
+			defer wg.Done()
+			r := lr.NewReader()
+			defer r.Close()
+			for !done.Load() {
+				r.Read(func(s *[]int) {
+					for i, v := range *s {
+						if v != i {
+							t.Errorf("Read saw a modified instance: %v", *s)
+							return
+						}
+					}
+				})
+			}
+		}()
+	}
+
+	for i := 0; i < iterations; i++ {
+		i := i
+		lr.Write(func(s *[]int) { *s = append(*s, i) })
+		if i%3 == 0 {
+			lr.Publish()
+		}
+	}
+	lr.Publish()
+	done.Store(true)
+	wg.Wait()
+
+	r := lr.NewReader()
+	r.Read(func(s *[]int) {
+		assert.Len(t, *s, iterations, "Lost writes.")
+	})
+	r.Close()
+	assert.Equal(t, 0, lr.readers.Len(), "Close didn't unregister every reader.")
+}
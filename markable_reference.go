@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+// MarkableReference is an atomic pair of a pointer and a boolean mark.
+//
+// Lock-free linked structures in the style of Harris use the mark to flag a
+// node as logically deleted. Keeping the mark next to the node's next
+// pointer means that once the mark is set, no CompareAndSwap that expects
+// it unset can link a new node after the deleted one.
+//
+// Like StampedPointer, it keeps the pair in an immutable box that every
+// change replaces, so each change allocates.
+//
+// The zero value holds a nil pointer and is unmarked.
+type MarkableReference[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Pointer[markableRef[T]]
+}
+
+type markableRef[T any] struct {
+	ptr    *T
+	marked bool
+}
+
+// NewMarkableReference creates a new MarkableReference holding ptr and
+// marked.
+func NewMarkableReference[T any](ptr *T, marked bool) *MarkableReference[T] {
+	var r MarkableReference[T]
+	r.Store(ptr, marked)
+	return &r
+}
+
+// Load atomically loads the pointer and its mark.
+func (r *MarkableReference[T]) Load() (ptr *T, marked bool) {
+	if ref := r.v.Load(); ref != nil {
+		return ref.ptr, ref.marked
+	}
+	return nil, false
+}
+
+// Store atomically stores ptr and marked.
+func (r *MarkableReference[T]) Store(ptr *T, marked bool) {
+	r.v.Store(&markableRef[T]{ptr: ptr, marked: marked})
+}
+
+// CompareAndSwap atomically replaces the pair with newPtr and newMark if it
+// currently holds oldPtr and oldMark. It reports whether it did.
+func (r *MarkableReference[T]) CompareAndSwap(oldPtr *T, oldMark bool, newPtr *T, newMark bool) (swapped bool) {
+	return casBox(&r.v, func(ref markableRef[T]) bool {
+		return ref.ptr == oldPtr && ref.marked == oldMark
+	}, markableRef[T]{ptr: newPtr, marked: newMark})
+}
+
+// AttemptMark atomically sets the mark to newMark if the pointer is
+// currently expectedPtr, whatever its mark. It reports whether it did.
+//
+// AttemptMark succeeds even if the mark already had the new value; to find
+// out which goroutine set the mark, use CompareAndSwap instead.
+func (r *MarkableReference[T]) AttemptMark(expectedPtr *T, newMark bool) bool {
+	return casBox(&r.v, func(ref markableRef[T]) bool {
+		return ref.ptr == expectedPtr
+	}, markableRef[T]{ptr: expectedPtr, marked: newMark})
+}
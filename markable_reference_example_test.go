@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// sortedSet is a lock-free set of ints kept in a sorted linked list,
+// after Harris. Removing a key first marks its node's next reference,
+// which deletes it logically, then unlinks it. Traversals unlink marked
+// nodes they pass.
+type sortedSet struct {
+	head setNode // sentinel
+}
+
+type setNode struct {
+	key  int
+	next atomic.MarkableReference[setNode]
+}
+
+// find returns the first unmarked node with a key of at least key, and its
+// predecessor.
+func (s *sortedSet) find(key int) (pred, curr *setNode) {
+retry:
+	for {
+		pred = &s.head
+		curr, _ = pred.next.Load()
+		for curr != nil {
+			succ, marked := curr.next.Load()
+			if marked {
+				if !pred.next.CompareAndSwap(curr, false, succ, false) {
+					continue retry // pred changed or was deleted
+				}
+				curr = succ
+				continue
+			}
+			if curr.key >= key {
+				return pred, curr
+			}
+			pred, curr = curr, succ
+		}
+		return pred, nil
+	}
+}
+
+func (s *sortedSet) Add(key int) bool {
+	for {
+		pred, curr := s.find(key)
+		if curr != nil && curr.key == key {
+			return false
+		}
+		n := &setNode{key: key}
+		n.next.Store(curr, false)
+		if pred.next.CompareAndSwap(curr, false, n, false) {
+			return true
+		}
+	}
+}
+
+func (s *sortedSet) Remove(key int) bool {
+	for {
+		pred, curr := s.find(key)
+		if curr == nil || curr.key != key {
+			return false
+		}
+		succ, _ := curr.next.Load()
+		// Only the goroutine that sets the mark removes the key.
+		if !curr.next.CompareAndSwap(succ, false, succ, true) {
+			continue
+		}
+		// Unlink the node; if that fails, a later find does it.
+		pred.next.CompareAndSwap(curr, false, succ, false)
+		return true
+	}
+}
+
+func (s *sortedSet) Contains(key int) bool {
+	curr, _ := s.head.next.Load()
+	for curr != nil && curr.key < key {
+		curr, _ = curr.next.Load()
+	}
+	if curr == nil || curr.key != key {
+		return false
+	}
+	_, marked := curr.next.Load()
+	return !marked
+}
+
+func (s *sortedSet) Keys() []int {
+	var keys []int
+	for curr, _ := s.head.next.Load(); curr != nil; {
+		next, marked := curr.next.Load()
+		if !marked {
+			keys = append(keys, curr.key)
+		}
+		curr = next
+	}
+	return keys
+}
+
+func ExampleMarkableReference() {
+	var s sortedSet
+	for _, k := range []int{3, 1, 4, 1, 5} {
+		s.Add(k)
+	}
+	fmt.Println(s.Remove(4), s.Remove(4))
+	fmt.Println(s.Contains(3), s.Contains(4))
+	fmt.Println(s.Keys())
+
+	// Output:
+	// true false
+	// true false
+	// [1 3 5]
+}
+
+func TestMarkableReferenceSortedSetStress(t *testing.T) {
+	const (
+		goroutines = 8
+		keys       = 32
+		iterations = 2000
+	)
+
+	var (
+		s   sortedSet
+		net [keys]atomic.Int64 // successful adds minus removes
+		wg  sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for i := 0; i < iterations; i++ {
+				k := r.Intn(keys)
+				switch r.Intn(3) {
+				case 0:
+					if s.Add(k) {
+						net[k].Inc()
+					}
+				case 1:
+					if s.Remove(k) {
+						net[k].Dec()
+					}
+				default:
+					s.Contains(k)
+				}
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+
+	got := s.Keys()
+	for i := 1; i < len(got); i++ {
+		require.True(t, got[i-1] < got[i], "Keys out of order or duplicated: %v", got)
+	}
+
+	var want []int
+	for k := range net {
+		n := net[k].Load()
+		require.True(t, n == 0 || n == 1, "Key %v added %v more times than removed.", k, n)
+		if n == 1 {
+			want = append(want, k)
+		}
+		assert.Equal(t, n == 1, s.Contains(k), "Contains(%v) disagrees with the history.", k)
+	}
+	assert.Equal(t, want, got, "Set holds the wrong keys.")
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkableReference(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var r MarkableReference[int]
+		ptr, marked := r.Load()
+		assert.Nil(t, ptr, "Zero value should hold a nil pointer.")
+		assert.False(t, marked, "Zero value shouldn't be marked.")
+
+		x := new(int)
+		assert.False(t, r.CompareAndSwap(nil, true, x, false), "CompareAndSwap succeeded with the wrong mark.")
+		require.True(t, r.CompareAndSwap(nil, false, x, true), "CompareAndSwap on the zero value failed.")
+		ptr, marked = r.Load()
+		assert.True(t, ptr == x, "CompareAndSwap didn't store the pointer.")
+		assert.True(t, marked, "CompareAndSwap didn't store the mark.")
+	})
+
+	a, b := new(int), new(int)
+	r := NewMarkableReference(a, false)
+
+	ptr, marked := r.Load()
+	assert.True(t, ptr == a, "NewMarkableReference didn't store the pointer.")
+	assert.False(t, marked, "NewMarkableReference didn't store the mark.")
+
+	assert.False(t, r.CompareAndSwap(b, false, b, true), "CompareAndSwap succeeded with the wrong pointer.")
+	require.True(t, r.CompareAndSwap(a, false, b, false), "CompareAndSwap failed.")
+	assert.True(t, r.CompareAndSwap(b, false, b, false), "CompareAndSwap to the same pair failed.")
+
+	t.Run("AttemptMark", func(t *testing.T) {
+		assert.False(t, r.AttemptMark(a, true), "AttemptMark succeeded with the wrong pointer.")
+		require.True(t, r.AttemptMark(b, true), "AttemptMark failed.")
+		assert.True(t, r.AttemptMark(b, true), "AttemptMark of an already marked pointer failed.")
+		ptr, marked := r.Load()
+		assert.True(t, ptr == b, "AttemptMark changed the pointer.")
+		assert.True(t, marked, "AttemptMark didn't set the mark.")
+
+		assert.False(t, r.CompareAndSwap(b, false, a, false), "CompareAndSwap succeeded on a marked pointer.")
+	})
+
+	t.Run("Store", func(t *testing.T) {
+		r.Store(a, false)
+		ptr, marked := r.Load()
+		assert.True(t, ptr == a, "Store didn't set the pointer.")
+		assert.False(t, marked, "Store didn't set the mark.")
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+// MPSCQueue is an unbounded multi-producer, single-consumer FIFO queue,
+// after Dmitry Vyukov's non-intrusive MPSC node-based queue.
+//
+// Any number of goroutines may call Enqueue, which is wait-free: it swaps
+// itself onto the head of the list and links the previous head to it,
+// without retrying. Only one goroutine at a time may call Dequeue or
+// DrainTo.
+//
+// Values are dequeued in the order their Enqueue calls swapped the head.
+// While an Enqueue is between those two steps, the consumer can't see
+// past it, so Dequeue may briefly report the queue as empty even though
+// later Enqueues have completed.
+//
+// The zero value is an empty queue ready to use.
+type MPSCQueue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	head Pointer[mpscNode[T]] // most recently enqueued node, or nil
+	tail *mpscNode[T]         // consumer-owned; nil means stub
+	stub mpscNode[T]
+}
+
+// mpscNode holds a value in the queue. The consumer's tail always points at
+// an already-dequeued node whose successor is the next value.
+type mpscNode[T any] struct {
+	next  Pointer[mpscNode[T]]
+	value T
+}
+
+// NewMPSCQueue creates an empty MPSCQueue.
+func NewMPSCQueue[T any]() *MPSCQueue[T] {
+	return &MPSCQueue[T]{}
+}
+
+// Enqueue adds value to the back of the queue. It's safe to call from any
+// goroutine.
+func (q *MPSCQueue[T]) Enqueue(value T) {
+	n := &mpscNode[T]{value: value}
+	prev := q.head.Swap(n)
+	if prev == nil {
+		prev = &q.stub
+	}
+	prev.next.Store(n)
+}
+
+// Dequeue removes and returns the value at the front of the queue. It
+// reports false if the queue is empty.
+//
+// Dequeue must only be called by the single consumer goroutine.
+func (q *MPSCQueue[T]) Dequeue() (value T, ok bool) {
+	tail := q.tail
+	if tail == nil {
+		tail = &q.stub
+	}
+	next := tail.next.Load()
+	if next == nil {
+		return value, false
+	}
+	// next becomes the new stub; drop its value so it can be collected.
+	q.tail = next
+	value, next.value = next.value, value
+	// Unlink the consumed node so that it doesn't keep the rest of the
+	// chain reachable. Producers have already written its next pointer, its
+	// only write, since the consumer got past it.
+	tail.next.Store(nil)
+	return value, true
+}
+
+// DrainTo dequeues values and passes them to fn until the queue is empty,
+// and reports how many it dequeued.
+//
+// DrainTo must only be called by the single consumer goroutine.
+func (q *MPSCQueue[T]) DrainTo(fn func(T)) (n int) {
+	for {
+		value, ok := q.Dequeue()
+		if !ok {
+			return n
+		}
+		fn(value)
+		n++
+	}
+}
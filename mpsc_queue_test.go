@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPSCQueue(t *testing.T) {
+	q := NewMPSCQueue[string]()
+	_, ok := q.Dequeue()
+	assert.False(t, ok, "Dequeue of an empty queue should report false.")
+
+	q.Enqueue("foo")
+	q.Enqueue("bar")
+	v, ok := q.Dequeue()
+	require.True(t, ok, "Dequeue of a non-empty queue should report true.")
+	assert.Equal(t, "foo", v, "Dequeue didn't return values in FIFO order.")
+
+	q.Enqueue("baz")
+	var got []string
+	n := q.DrainTo(func(v string) { got = append(got, v) })
+	assert.Equal(t, 2, n, "DrainTo reported the wrong count.")
+	assert.Equal(t, []string{"bar", "baz"}, got, "DrainTo didn't return values in FIFO order.")
+
+	_, ok = q.Dequeue()
+	assert.False(t, ok, "DrainTo didn't empty the queue.")
+	assert.Equal(t, 0, q.DrainTo(func(string) {}), "DrainTo of an empty queue reported values.")
+
+	// Consumed nodes must not stay reachable from the queue.
+	assert.Nil(t, q.stub.next.Load(), "Stub still links to dequeued nodes.")
+	assert.Nil(t, q.tail.next.Load(), "Drained queue links past its last node.")
+}
+
+func TestMPSCQueueConcurrent(t *testing.T) {
+	const (
+		producers  = 4
+		iterations = 1000
+	)
+
+	var q MPSCQueue[[2]int]
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for p := 0; p < producers; p++ {
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				q.Enqueue([2]int{p, i})
+			}
+		}(p)
+	}
+
+	next := make([]int, producers)
+	for received := 0; received < producers*iterations; {
+		n := q.DrainTo(func(v [2]int) {
+			p, i := v[0], v[1]
+			assert.Equal(t, next[p], i, "Values from producer %d out of order.", p)
+			next[p] = i + 1
+		})
+		if n == 0 {
+			runtime.Gosched()
+		}
+		received += n
+	}
+	wg.Wait()
+
+	_, ok := q.Dequeue()
+	assert.False(t, ok, "Queue should be empty after receiving every value.")
+	for p, n := range next {
+		assert.Equal(t, iterations, n, "Lost values from producer %d.", p)
+	}
+}
+
+func BenchmarkMPSCQueue(b *testing.B) {
+	b.Run("MPSCQueue", func(b *testing.B) {
+		var (
+			q    MPSCQueue[int]
+			stop Bool
+			done = make(chan struct{})
+		)
+		go func() {
+			defer close(done)
+			for !stop.Load() {
+				if q.DrainTo(func(int) {}) == 0 {
+					runtime.Gosched()
+				}
+			}
+		}()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				q.Enqueue(1)
+			}
+		})
+		stop.Store(true)
+		<-done
+	})
+
+	b.Run("chan", func(b *testing.B) {
+		var (
+			ch   = make(chan int, 1024)
+			done = make(chan struct{})
+		)
+		go func() {
+			defer close(done)
+			for range ch {
+			}
+		}()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				ch <- 1
+			}
+		})
+		close(ch)
+		<-done
+	})
+}
@@ -53,15 +53,25 @@ func TestNocmpComparability(t *testing.T) {
 		},
 
 		// All exported types must be uncomparable.
+		{desc: "Bitfields", give: Bitfields{}},
+		{desc: "BloomFilter", give: BloomFilter{}},
 		{desc: "Bool", give: Bool{}},
+		{desc: "CountMinSketch", give: CountMinSketch{}},
 		{desc: "Duration", give: Duration{}},
 		{desc: "Error", give: Error{}},
 		{desc: "Float64", give: Float64{}},
+		{desc: "Float64Array", give: Float64Array{}},
+		{desc: "HyperLogLog", give: HyperLogLog{}},
 		{desc: "Int32", give: Int32{}},
 		{desc: "Int64", give: Int64{}},
+		{desc: "Int64Array", give: Int64Array{}},
+		{desc: "Pair32", give: Pair32{}},
+		{desc: "RCU", give: RCU{}},
+		{desc: "SignedPair32", give: SignedPair32{}},
 		{desc: "String", give: String{}},
 		{desc: "Uint32", give: Uint32{}},
 		{desc: "Uint64", give: Uint64{}},
+		{desc: "Uint64Array", give: Uint64Array{}},
 		{desc: "Value", give: Value{}},
 	}
 
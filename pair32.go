@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Pair32 is an atomic pair of uint32 values packed into a single Uint64.
+// Both halves are always loaded and stored together.
+type Pair32 struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Uint64
+}
+
+func packPair32(a, b uint32) uint64 {
+	return uint64(a)<<32 | uint64(b)
+}
+
+func unpackPair32(v uint64) (a, b uint32) {
+	return uint32(v >> 32), uint32(v)
+}
+
+// NewPair32 creates a new Pair32.
+func NewPair32(a, b uint32) *Pair32 {
+	p := &Pair32{}
+	p.v.Store(packPair32(a, b))
+	return p
+}
+
+// Load atomically loads both halves of the pair.
+func (p *Pair32) Load() (a, b uint32) {
+	return unpackPair32(p.v.Load())
+}
+
+// Store atomically stores both halves of the pair.
+func (p *Pair32) Store(a, b uint32) {
+	p.v.Store(packPair32(a, b))
+}
+
+// Swap atomically stores both halves of the pair and returns the old
+// values.
+func (p *Pair32) Swap(a, b uint32) (oldA, oldB uint32) {
+	return unpackPair32(p.v.Swap(packPair32(a, b)))
+}
+
+// CompareAndSwap is an atomic compare-and-swap of both halves of the pair.
+func (p *Pair32) CompareAndSwap(oldA, oldB, newA, newB uint32) (swapped bool) {
+	return p.v.CompareAndSwap(packPair32(oldA, oldB), packPair32(newA, newB))
+}
+
+// AddA atomically adds to the first half of the pair and returns the new
+// pair. The first half wraps around on overflow without affecting the second.
+func (p *Pair32) AddA(delta uint32) (a, b uint32) {
+	for {
+		old := p.v.Load()
+		a, b = unpackPair32(old)
+		a += delta
+		if p.v.CompareAndSwap(old, packPair32(a, b)) {
+			return a, b
+		}
+	}
+}
+
+// AddB atomically adds to the second half of the pair and returns the new
+// pair. The second half wraps around on overflow without affecting the first.
+func (p *Pair32) AddB(delta uint32) (a, b uint32) {
+	for {
+		old := p.v.Load()
+		a, b = unpackPair32(old)
+		b += delta
+		if p.v.CompareAndSwap(old, packPair32(a, b)) {
+			return a, b
+		}
+	}
+}
+
+// MarshalJSON encodes the wrapped pair into a two-element JSON array.
+func (p *Pair32) MarshalJSON() ([]byte, error) {
+	a, b := p.Load()
+	return json.Marshal([2]uint32{a, b})
+}
+
+// UnmarshalJSON decodes a two-element JSON array into the wrapped pair.
+func (p *Pair32) UnmarshalJSON(data []byte) error {
+	var v [2]uint32
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	p.Store(v[0], v[1])
+	return nil
+}
+
+// String encodes the wrapped pair as a string.
+func (p *Pair32) String() string {
+	a, b := p.Load()
+	return fmt.Sprintf("(%d, %d)", a, b)
+}
+
+// SignedPair32 is an atomic pair of int32 values packed into a single
+// Uint64. Both halves are always loaded and stored together.
+type SignedPair32 struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Pair32
+}
+
+// NewSignedPair32 creates a new SignedPair32.
+func NewSignedPair32(a, b int32) *SignedPair32 {
+	p := &SignedPair32{}
+	p.Store(a, b)
+	return p
+}
+
+// Load atomically loads both halves of the pair.
+func (p *SignedPair32) Load() (a, b int32) {
+	ua, ub := p.v.Load()
+	return int32(ua), int32(ub)
+}
+
+// Store atomically stores both halves of the pair.
+func (p *SignedPair32) Store(a, b int32) {
+	p.v.Store(uint32(a), uint32(b))
+}
+
+// Swap atomically stores both halves of the pair and returns the old
+// values.
+func (p *SignedPair32) Swap(a, b int32) (oldA, oldB int32) {
+	ua, ub := p.v.Swap(uint32(a), uint32(b))
+	return int32(ua), int32(ub)
+}
+
+// CompareAndSwap is an atomic compare-and-swap of both halves of the pair.
+func (p *SignedPair32) CompareAndSwap(oldA, oldB, newA, newB int32) (swapped bool) {
+	return p.v.CompareAndSwap(uint32(oldA), uint32(oldB), uint32(newA), uint32(newB))
+}
+
+// AddA atomically adds to the first half of the pair and returns the new
+// pair. The first half wraps around on overflow without affecting the second.
+func (p *SignedPair32) AddA(delta int32) (a, b int32) {
+	ua, ub := p.v.AddA(uint32(delta))
+	return int32(ua), int32(ub)
+}
+
+// AddB atomically adds to the second half of the pair and returns the new
+// pair. The second half wraps around on overflow without affecting the first.
+func (p *SignedPair32) AddB(delta int32) (a, b int32) {
+	ua, ub := p.v.AddB(uint32(delta))
+	return int32(ua), int32(ub)
+}
+
+// MarshalJSON encodes the wrapped pair into a two-element JSON array.
+func (p *SignedPair32) MarshalJSON() ([]byte, error) {
+	a, b := p.Load()
+	return json.Marshal([2]int32{a, b})
+}
+
+// UnmarshalJSON decodes a two-element JSON array into the wrapped pair.
+func (p *SignedPair32) UnmarshalJSON(data []byte) error {
+	var v [2]int32
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	p.Store(v[0], v[1])
+	return nil
+}
+
+// String encodes the wrapped pair as a string.
+func (p *SignedPair32) String() string {
+	a, b := p.Load()
+	return fmt.Sprintf("(%d, %d)", a, b)
+}
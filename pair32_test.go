@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pair32 and signedPair32 collect the two halves of a pair into an array
+// so that they can be compared in one assertion.
+func pair32(a, b uint32) [2]uint32 { return [2]uint32{a, b} }
+
+func signedPair32(a, b int32) [2]int32 { return [2]int32{a, b} }
+
+func TestPair32(t *testing.T) {
+	atom := NewPair32(1, 2)
+
+	require.Equal(t, [2]uint32{1, 2}, pair32(atom.Load()), "Load didn't work.")
+
+	require.Equal(t, [2]uint32{4, 2}, pair32(atom.AddA(3)), "AddA didn't work.")
+	require.Equal(t, [2]uint32{4, 7}, pair32(atom.AddB(5)), "AddB didn't work.")
+
+	require.False(t, atom.CompareAndSwap(4, 2, 0, 0), "CAS of a mismatched half reported a swap.")
+	require.True(t, atom.CompareAndSwap(4, 7, 8, 9), "CAS didn't report a swap.")
+	require.Equal(t, [2]uint32{8, 9}, pair32(atom.Load()), "CAS didn't set the correct value.")
+
+	require.Equal(t, [2]uint32{8, 9}, pair32(atom.Swap(10, 11)), "Swap didn't return the old value.")
+	require.Equal(t, [2]uint32{10, 11}, pair32(atom.Load()), "Swap didn't set the correct value.")
+
+	atom.Store(math.MaxUint32, math.MaxUint32)
+	require.Equal(t, [2]uint32{math.MaxUint32, 0}, pair32(atom.AddB(1)), "AddB carried into the first half.")
+	require.Equal(t, [2]uint32{1, 0}, pair32(atom.AddA(2)), "AddA didn't wrap around within the first half.")
+
+	t.Run("JSON/Marshal", func(t *testing.T) {
+		atom.Store(1, 2)
+		bytes, err := json.Marshal(atom)
+		require.NoError(t, err, "json.Marshal errored unexpectedly.")
+		require.Equal(t, []byte("[1,2]"), bytes, "json.Marshal encoded the wrong bytes.")
+	})
+
+	t.Run("JSON/Unmarshal", func(t *testing.T) {
+		err := json.Unmarshal([]byte("[3,4]"), &atom)
+		require.NoError(t, err, "json.Unmarshal errored unexpectedly.")
+		require.Equal(t, [2]uint32{3, 4}, pair32(atom.Load()), "json.Unmarshal didn't set the correct value.")
+	})
+
+	t.Run("JSON/Unmarshal/Error", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`["3",4]`), &atom)
+		require.Error(t, err, "json.Unmarshal didn't error as expected.")
+		assertErrorJSONUnmarshalType(t, err,
+			"json.Unmarshal failed with unexpected error %v, want UnmarshalTypeError.", err)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, "(42, 7)", NewPair32(42, 7).String(),
+			"String() returned an unexpected value.")
+	})
+}
+
+func TestSignedPair32(t *testing.T) {
+	atom := NewSignedPair32(-1, 2)
+
+	require.Equal(t, [2]int32{-1, 2}, signedPair32(atom.Load()), "Load didn't work.")
+
+	require.Equal(t, [2]int32{-4, 2}, signedPair32(atom.AddA(-3)), "AddA didn't work.")
+	require.Equal(t, [2]int32{-4, -3}, signedPair32(atom.AddB(-5)), "AddB didn't work.")
+
+	require.True(t, atom.CompareAndSwap(-4, -3, 8, -9), "CAS didn't report a swap.")
+	require.Equal(t, [2]int32{8, -9}, signedPair32(atom.Swap(10, 11)), "Swap didn't return the old value.")
+
+	atom.Store(math.MinInt32, math.MaxInt32)
+	require.Equal(t, [2]int32{math.MinInt32, math.MinInt32}, signedPair32(atom.AddB(1)), "AddB didn't wrap around within the second half.")
+	require.Equal(t, [2]int32{math.MaxInt32, math.MinInt32}, signedPair32(atom.AddA(-1)), "AddA borrowed from the second half.")
+
+	t.Run("JSON", func(t *testing.T) {
+		atom.Store(-1, 2)
+		bytes, err := json.Marshal(atom)
+		require.NoError(t, err, "json.Marshal errored unexpectedly.")
+		require.Equal(t, []byte("[-1,2]"), bytes, "json.Marshal encoded the wrong bytes.")
+
+		require.NoError(t, json.Unmarshal([]byte("[3,-4]"), &atom), "json.Unmarshal errored unexpectedly.")
+		require.Equal(t, [2]int32{3, -4}, signedPair32(atom.Load()), "json.Unmarshal didn't set the correct value.")
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, "(-42, 7)", NewSignedPair32(-42, 7).String(),
+			"String() returned an unexpected value.")
+	})
+}
@@ -47,3 +47,54 @@ func (p *Pointer[T]) UnmarshalJSON(b []byte) error {
 	p.Store(&v)
 	return nil
 }
+
+// Modify atomically replaces the wrapped pointer with the one returned by fn,
+// which is given the current pointer. If another goroutine changes the
+// pointer first, Modify calls fn again with the new pointer until its
+// compare-and-swap succeeds, so fn must not have side effects beyond
+// building its result.
+//
+// fn must not modify the value it is given: other goroutines may be reading
+// it. Use ModifyClone to work on a private copy instead.
+//
+// If fn returns an error, Modify stops without changing the pointer and
+// returns the error. Modify reports the number of times it called fn.
+func (p *Pointer[T]) Modify(fn func(cur *T) (*T, error)) (attempts int, err error) {
+	for {
+		attempts++
+		cur := p.Load()
+		next, err := fn(cur)
+		if err != nil {
+			return attempts, err
+		}
+		if p.CompareAndSwap(cur, next) {
+			return attempts, nil
+		}
+	}
+}
+
+// ModifyClone is like Modify, but gives fn a private copy of the current
+// value to mutate in place. The copy is made by clone, which must return a
+// copy that shares no mutable state with its argument. If clone is nil, the
+// value is copied shallowly. The current pointer may be nil, in which case
+// clone must return a fresh value; the default gives fn a new zero value.
+func (p *Pointer[T]) ModifyClone(clone func(cur *T) *T, fn func(next *T) error) (attempts int, err error) {
+	if clone == nil {
+		clone = shallowClone[T]
+	}
+	return p.Modify(func(cur *T) (*T, error) {
+		next := clone(cur)
+		if err := fn(next); err != nil {
+			return nil, err
+		}
+		return next, nil
+	})
+}
+
+func shallowClone[T any](cur *T) *T {
+	next := new(T)
+	if cur != nil {
+		*next = *cur
+	}
+	return next
+}
@@ -25,9 +25,12 @@ package atomic
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -122,3 +125,124 @@ func TestPointer(t *testing.T) {
 		require.Error(t, json.Unmarshal([]byte(`{"v":true}`), &p), "json.Unmarshal should return an error")
 	})
 }
+
+func TestPointerModify(t *testing.T) {
+	type config struct {
+		Version int
+		Tags    []string
+	}
+
+	t.Run("Modify", func(t *testing.T) {
+		p := NewPointer(&config{Version: 1})
+		attempts, err := p.Modify(func(cur *config) (*config, error) {
+			return &config{Version: cur.Version + 1}, nil
+		})
+		require.NoError(t, err, "Modify failed.")
+		assert.Equal(t, 1, attempts, "Uncontended Modify should succeed on the first attempt.")
+		assert.Equal(t, 2, p.Load().Version, "Modify didn't store the new value.")
+	})
+
+	t.Run("Modify/error", func(t *testing.T) {
+		orig := &config{Version: 1}
+		p := NewPointer(orig)
+		wantErr := errors.New("great sadness")
+		attempts, err := p.Modify(func(cur *config) (*config, error) {
+			return &config{}, wantErr
+		})
+		assert.Equal(t, wantErr, err, "Modify didn't return the error from fn.")
+		assert.Equal(t, 1, attempts, "Modify should stop after an error.")
+		assert.True(t, orig == p.Load(), "Failed Modify changed the pointer.")
+	})
+
+	t.Run("Modify/retry", func(t *testing.T) {
+		p := NewPointer(&config{Version: 1})
+		attempts, err := p.Modify(func(cur *config) (*config, error) {
+			if cur.Version == 1 {
+				// Simulate another goroutine winning the race.
+				p.Store(&config{Version: 10})
+			}
+			return &config{Version: cur.Version + 1}, nil
+		})
+		require.NoError(t, err, "Modify failed.")
+		assert.Equal(t, 2, attempts, "Modify should retry after a conflict.")
+		assert.Equal(t, 11, p.Load().Version, "Modify didn't build on the latest value.")
+	})
+
+	t.Run("ModifyClone", func(t *testing.T) {
+		orig := &config{Version: 1, Tags: []string{"a"}}
+		p := NewPointer(orig)
+		_, err := p.ModifyClone(func(cur *config) *config {
+			return &config{Version: cur.Version, Tags: append([]string(nil), cur.Tags...)}
+		}, func(next *config) error {
+			next.Version++
+			next.Tags[0] = "b"
+			return nil
+		})
+		require.NoError(t, err, "ModifyClone failed.")
+		assert.Equal(t, &config{Version: 1, Tags: []string{"a"}}, orig, "ModifyClone mutated the shared snapshot.")
+		assert.Equal(t, &config{Version: 2, Tags: []string{"b"}}, p.Load(), "ModifyClone didn't store the new value.")
+	})
+
+	t.Run("ModifyClone/default", func(t *testing.T) {
+		var p Pointer[config]
+		_, err := p.ModifyClone(nil, func(next *config) error {
+			next.Version = 1
+			return nil
+		})
+		require.NoError(t, err, "ModifyClone failed.")
+		orig := p.Load()
+		assert.Equal(t, 1, orig.Version, "ModifyClone didn't start from a zero value.")
+
+		_, err = p.ModifyClone(nil, func(next *config) error {
+			next.Version++
+			return nil
+		})
+		require.NoError(t, err, "ModifyClone failed.")
+		assert.Equal(t, 1, orig.Version, "ModifyClone mutated the shared snapshot.")
+		assert.Equal(t, 2, p.Load().Version, "ModifyClone didn't store the new value.")
+	})
+
+	t.Run("ModifyClone/error", func(t *testing.T) {
+		orig := &config{Version: 1}
+		p := NewPointer(orig)
+		wantErr := errors.New("great sadness")
+		_, err := p.ModifyClone(nil, func(next *config) error {
+			next.Version = 2
+			return wantErr
+		})
+		assert.Equal(t, wantErr, err, "ModifyClone didn't return the error from fn.")
+		assert.True(t, orig == p.Load(), "Failed ModifyClone changed the pointer.")
+		assert.Equal(t, 1, orig.Version, "Failed ModifyClone mutated the shared snapshot.")
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		const (
+			goroutines = 8
+			iterations = 1000
+		)
+
+		p := NewPointer(&config{})
+		var (
+			wg       sync.WaitGroup
+			attempts Int64
+		)
+		wg.Add(goroutines)
+		for i := 0; i < goroutines; i++ {
+			go func() {
+				defer wg.Done()
+				for j := 0; j < iterations; j++ {
+					n, err := p.ModifyClone(nil, func(next *config) error {
+						next.Version++
+						return nil
+					})
+					assert.NoError(t, err)
+					attempts.Add(int64(n))
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, goroutines*iterations, p.Load().Version, "Lost concurrent updates.")
+		assert.True(t, attempts.Load() >= goroutines*iterations, "Attempts undercounted.")
+	})
+}
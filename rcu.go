@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"runtime"
+	"sync"
+)
+
+// RCU tracks read-side critical sections so that writers can tell when
+// old data is no longer in use, as in read-copy-update.
+//
+// A typical writer publishes a new version of some data through a Pointer,
+// then waits with Synchronize, or schedules cleanup with Call, before
+// reusing or releasing the old version. Readers bracket their use of the
+// data with ReadLock and ReadUnlock:
+//
+//	tok := rcu.ReadLock()
+//	cfg := current.Load()
+//	// ... use cfg ...
+//	rcu.ReadUnlock(tok)
+//
+// Read-side critical sections are counted on per-P stripes, selected
+// through a sync.Pool, so readers on different Ps rarely touch the same
+// cache line. Each grace period flips a phase bit: new readers count
+// themselves under the new phase, and Synchronize waits for the counts of
+// the old phase to drain.
+//
+// The zero value is not usable; create RCUs with NewRCU.
+type RCU struct {
+	_ nocmp // disallow non-atomic comparison
+
+	phase   Uint64
+	stripes []rcuStripe
+	hints   sync.Pool // of *int stripe indexes
+	next    Uint64    // next stripe index to hand out
+
+	syncMu sync.Mutex // serializes grace periods
+
+	callMu    sync.Mutex
+	pending   []func()
+	reclaimer bool // whether the reclaiming goroutine is running
+	calls     sync.WaitGroup
+}
+
+type rcuStripe struct {
+	counts [2]Int64 // readers per phase parity
+	_      [_cacheLineSize - 16]byte
+}
+
+// RCUToken records the read-side critical section started by ReadLock.
+// Pass it to ReadUnlock.
+type RCUToken struct {
+	stripe *rcuStripe
+	phase  uint64
+}
+
+// NewRCU creates a new RCU.
+func NewRCU() *RCU {
+	n := 1
+	for n < runtime.GOMAXPROCS(0) {
+		n *= 2
+	}
+	return &RCU{stripes: make([]rcuStripe, n)}
+}
+
+// ReadLock starts a read-side critical section. It never blocks.
+func (r *RCU) ReadLock() RCUToken {
+	s := r.stripe()
+	for {
+		phase := r.phase.Load() & 1
+		s.counts[phase].Inc()
+		// If a grace period started meanwhile, it may already have found
+		// the old phase drained, so count ourselves under the new one.
+		if r.phase.Load()&1 == phase {
+			return RCUToken{stripe: s, phase: phase}
+		}
+		s.counts[phase].Dec()
+	}
+}
+
+// ReadUnlock ends the read-side critical section started by ReadLock.
+func (r *RCU) ReadUnlock(tok RCUToken) {
+	tok.stripe.counts[tok.phase].Dec()
+}
+
+func (r *RCU) stripe() *rcuStripe {
+	// sync.Pool keeps a cache per P, so goroutines running on the same P
+	// mostly get the same hint back.
+	hint, _ := r.hints.Get().(*int)
+	if hint == nil {
+		i := int(r.next.Inc()-1) & (len(r.stripes) - 1)
+		hint = &i
+	}
+	r.hints.Put(hint)
+	return &r.stripes[*hint]
+}
+
+// Synchronize waits for a grace period: it returns once every read-side
+// critical section that started before it was called has ended. It must
+// not be called inside a read-side critical section.
+func (r *RCU) Synchronize() {
+	r.syncMu.Lock()
+	defer r.syncMu.Unlock()
+
+	old := r.phase.Inc() - 1
+	for i := range r.stripes {
+		for r.stripes[i].counts[old&1].Load() != 0 {
+			runtime.Gosched()
+		}
+	}
+}
+
+// Call schedules fn to run on a background goroutine after a grace
+// period, once no reader can still be using data unpublished before Call.
+// Callbacks queued together share a grace period.
+func (r *RCU) Call(fn func()) {
+	r.calls.Add(1)
+
+	r.callMu.Lock()
+	r.pending = append(r.pending, fn)
+	start := !r.reclaimer
+	r.reclaimer = true
+	r.callMu.Unlock()
+
+	if start {
+		go r.reclaim()
+	}
+}
+
+// Barrier waits until every callback passed to Call has run.
+func (r *RCU) Barrier() {
+	r.calls.Wait()
+}
+
+func (r *RCU) reclaim() {
+	for {
+		r.callMu.Lock()
+		batch := r.pending
+		r.pending = nil
+		if len(batch) == 0 {
+			r.reclaimer = false
+			r.callMu.Unlock()
+			return
+		}
+		r.callMu.Unlock()
+
+		r.Synchronize()
+		for _, fn := range batch {
+			fn()
+			r.calls.Done()
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRCU(t *testing.T) {
+	t.Run("Synchronize without readers", func(t *testing.T) {
+		r := NewRCU()
+		r.Synchronize()
+		r.Synchronize()
+	})
+
+	t.Run("Synchronize waits for readers", func(t *testing.T) {
+		r := NewRCU()
+		tok := r.ReadLock()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			r.Synchronize()
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("Synchronize returned while a reader was active.")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		// Readers that start after the grace period began don't hold it up.
+		late := r.ReadLock()
+		r.ReadUnlock(tok)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Synchronize didn't return after the reader finished.")
+		}
+		r.ReadUnlock(late)
+	})
+
+	t.Run("Call", func(t *testing.T) {
+		r := NewRCU()
+		tok := r.ReadLock()
+
+		var ran Int32
+		for i := 0; i < 3; i++ {
+			r.Call(func() { ran.Inc() })
+		}
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, int32(0), ran.Load(), "Call ran fn while a reader was active.")
+
+		r.ReadUnlock(tok)
+		r.Barrier()
+		assert.Equal(t, int32(3), ran.Load(), "Barrier returned before every callback ran.")
+
+		// The reclaimer restarts once it has gone idle.
+		r.Call(func() { ran.Inc() })
+		r.Barrier()
+		assert.Equal(t, int32(4), ran.Load(), "Call after the reclaimer exited didn't run fn.")
+	})
+}
+
+func TestRCUConcurrent(t *testing.T) {
+	const (
+		readers  = 8
+		versions = 200
+	)
+
+	type resource struct {
+		id     int
+		closed Bool
+	}
+
+	r := NewRCU()
+	var current Value
+	current.Store(&resource{})
+
+	var (
+		wg     sync.WaitGroup
+		stop   Bool
+		misuse Int32
+	)
+	wg.Add(readers)
+	for i := 0; i < readers; i++ {
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				tok := r.ReadLock()
+				res := current.Load().(*resource)
+				if res.closed.Load() {
+					misuse.Inc()
+				}
+				r.ReadUnlock(tok)
+			}
+		}()
+	}
+
+	for i := 1; i <= versions; i++ {
+		old := current.Swap(&resource{id: i}).(*resource)
+		if i%2 == 0 {
+			r.Synchronize()
+			old.closed.Store(true)
+		} else {
+			r.Call(func() { old.closed.Store(true) })
+		}
+	}
+	r.Barrier()
+	stop.Store(true)
+	wg.Wait()
+
+	require.Equal(t, int32(0), misuse.Load(), "Readers saw reclaimed resources.")
+}
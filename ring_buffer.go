@@ -0,0 +1,331 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import "fmt"
+
+// RingBuffer is a bounded FIFO queue with a fixed, power-of-two capacity.
+// Implementations don't allocate after they're created, and never block:
+// pushing to a full buffer or popping from an empty one fails immediately.
+type RingBuffer[T any] interface {
+	// TryPush adds value to the back of the buffer, and reports false if
+	// the buffer is full.
+	TryPush(value T) bool
+
+	// TryPop removes and returns the value at the front of the buffer, and
+	// reports false if the buffer is empty.
+	TryPop() (value T, ok bool)
+
+	// TryPushBatch adds as many of values as fit, in order, and reports
+	// how many it added.
+	TryPushBatch(values []T) int
+
+	// TryPopBatch removes up to len(values) values into values, in order,
+	// and reports how many it removed.
+	TryPopBatch(values []T) int
+
+	// Cap reports the capacity of the buffer.
+	Cap() int
+
+	// Len reports the number of values in the buffer. Under concurrent
+	// use it's only an approximation.
+	Len() int
+}
+
+var (
+	_ RingBuffer[int] = (*SPSCRingBuffer[int])(nil)
+	_ RingBuffer[int] = (*MPMCRingBuffer[int])(nil)
+)
+
+// newRingSeqs returns the sequence numbers of a ring buffer's slots, which
+// say whose turn it is: for the value at position pos, seq is pos while the
+// slot is free for the producer, and pos+1 once the value is ready for the
+// consumer. Popping the value sets seq to pos+capacity, freeing the slot
+// for the producer of the next lap.
+//
+// The sequence numbers live in their own slice rather than next to each
+// value: the size of a generic slot can't be padded to a multiple of 8
+// bytes, so some of them would be misaligned on 32-bit platforms.
+func newRingSeqs(capacity int) []Uint64 {
+	if capacity < 2 || capacity&(capacity-1) != 0 {
+		panic(fmt.Sprintf("atomic: ring buffer capacity must be a power of two of at least 2, got %d", capacity))
+	}
+	seqs := make([]Uint64, capacity)
+	for i := range seqs {
+		seqs[i].Store(uint64(i))
+	}
+	return seqs
+}
+
+func ringLen(head, tail *Uint64, capacity int) int {
+	h := head.Load()
+	t := tail.Load()
+	switch n := int64(t - h); {
+	case n < 0:
+		return 0
+	case n > int64(capacity):
+		return capacity
+	default:
+		return int(n)
+	}
+}
+
+// SPSCRingBuffer is a RingBuffer for exactly one producer goroutine and
+// one consumer goroutine. Neither side ever retries: each operation is a
+// load and a store of the slot's sequence number.
+//
+// The zero value is not usable; create SPSCRingBuffers with
+// NewSPSCRingBuffer.
+type SPSCRingBuffer[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	_    cacheLinePad
+	head Uint64 // written only by the consumer
+	_    cacheLinePad
+	tail Uint64 // written only by the producer
+	_    cacheLinePad
+
+	mask   uint64
+	seqs   []Uint64
+	values []T
+}
+
+// NewSPSCRingBuffer creates an empty SPSCRingBuffer. It panics if capacity
+// isn't a power of two of at least 2.
+func NewSPSCRingBuffer[T any](capacity int) *SPSCRingBuffer[T] {
+	return &SPSCRingBuffer[T]{
+		mask:   uint64(capacity - 1),
+		seqs:   newRingSeqs(capacity),
+		values: make([]T, capacity),
+	}
+}
+
+// TryPush adds value to the back of the buffer, and reports false if the
+// buffer is full. Only the producer goroutine may call it.
+func (r *SPSCRingBuffer[T]) TryPush(value T) bool {
+	pos := r.tail.Load()
+	i := pos & r.mask
+	if r.seqs[i].Load() != pos {
+		return false
+	}
+	r.values[i] = value
+	r.seqs[i].Store(pos + 1) // publishes value to the consumer
+	r.tail.Store(pos + 1)
+	return true
+}
+
+// TryPop removes and returns the value at the front of the buffer, and
+// reports false if the buffer is empty. Only the consumer goroutine may
+// call it.
+func (r *SPSCRingBuffer[T]) TryPop() (value T, ok bool) {
+	pos := r.head.Load()
+	i := pos & r.mask
+	if r.seqs[i].Load() != pos+1 {
+		return value, false
+	}
+	value, r.values[i] = r.values[i], value
+	r.seqs[i].Store(pos + r.mask + 1) // hands the slot back to the producer
+	r.head.Store(pos + 1)
+	return value, true
+}
+
+// TryPushBatch adds as many of values as fit, in order, and reports how
+// many it added. Only the producer goroutine may call it.
+func (r *SPSCRingBuffer[T]) TryPushBatch(values []T) int {
+	for i, v := range values {
+		if !r.TryPush(v) {
+			return i
+		}
+	}
+	return len(values)
+}
+
+// TryPopBatch removes up to len(values) values into values, in order, and
+// reports how many it removed. Only the consumer goroutine may call it.
+func (r *SPSCRingBuffer[T]) TryPopBatch(values []T) int {
+	for i := range values {
+		v, ok := r.TryPop()
+		if !ok {
+			return i
+		}
+		values[i] = v
+	}
+	return len(values)
+}
+
+// Cap reports the capacity of the buffer.
+func (r *SPSCRingBuffer[T]) Cap() int {
+	return len(r.seqs)
+}
+
+// Len reports the number of values in the buffer.
+func (r *SPSCRingBuffer[T]) Len() int {
+	return ringLen(&r.head, &r.tail, len(r.seqs))
+}
+
+// MPMCRingBuffer is a RingBuffer that any number of goroutines may push to
+// and pop from concurrently, after Dmitry Vyukov's bounded MPMC queue.
+//
+// Producers and consumers each claim positions with a compare-and-swap on
+// tail or head, then use the slot's sequence number to wait their turn
+// without touching the other side's counter.
+//
+// The zero value is not usable; create MPMCRingBuffers with
+// NewMPMCRingBuffer.
+type MPMCRingBuffer[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	_    cacheLinePad
+	head Uint64
+	_    cacheLinePad
+	tail Uint64
+	_    cacheLinePad
+
+	mask   uint64
+	seqs   []Uint64
+	values []T
+}
+
+// NewMPMCRingBuffer creates an empty MPMCRingBuffer. It panics if capacity
+// isn't a power of two of at least 2.
+func NewMPMCRingBuffer[T any](capacity int) *MPMCRingBuffer[T] {
+	return &MPMCRingBuffer[T]{
+		mask:   uint64(capacity - 1),
+		seqs:   newRingSeqs(capacity),
+		values: make([]T, capacity),
+	}
+}
+
+// TryPush adds value to the back of the buffer, and reports false if the
+// buffer is full.
+func (r *MPMCRingBuffer[T]) TryPush(value T) bool {
+	pos, ok := r.claim(&r.tail, 0)
+	if !ok {
+		return false
+	}
+	i := pos & r.mask
+	r.values[i] = value
+	r.seqs[i].Store(pos + 1)
+	return true
+}
+
+// TryPop removes and returns the value at the front of the buffer, and
+// reports false if the buffer is empty.
+func (r *MPMCRingBuffer[T]) TryPop() (value T, ok bool) {
+	pos, ok := r.claim(&r.head, 1)
+	if !ok {
+		return value, false
+	}
+	i := pos & r.mask
+	value, r.values[i] = r.values[i], value
+	r.seqs[i].Store(pos + r.mask + 1)
+	return value, true
+}
+
+// TryPushBatch adds as many of values as fit, in order, and reports how
+// many it added. The values occupy consecutive positions in the buffer.
+func (r *MPMCRingBuffer[T]) TryPushBatch(values []T) int {
+	pos, n := r.claimBatch(&r.tail, 0, len(values))
+	for i := 0; i < n; i++ {
+		p := pos + uint64(i)
+		r.values[p&r.mask] = values[i]
+		r.seqs[p&r.mask].Store(p + 1)
+	}
+	return n
+}
+
+// TryPopBatch removes up to len(values) values into values, in order, and
+// reports how many it removed. The values come from consecutive positions
+// in the buffer.
+func (r *MPMCRingBuffer[T]) TryPopBatch(values []T) int {
+	var zero T
+	pos, n := r.claimBatch(&r.head, 1, len(values))
+	for i := 0; i < n; i++ {
+		p := pos + uint64(i)
+		values[i], r.values[p&r.mask] = r.values[p&r.mask], zero
+		r.seqs[p&r.mask].Store(p + r.mask + 1)
+	}
+	return n
+}
+
+// claim reserves the next position from counter, whose slot must have
+// sequence number pos+ready. It reports false if that slot isn't ready,
+// which means the buffer is full (for producers) or empty (for consumers).
+func (r *MPMCRingBuffer[T]) claim(counter *Uint64, ready uint64) (uint64, bool) {
+	pos := counter.Load()
+	for {
+		seq := r.seqs[pos&r.mask].Load()
+		switch d := int64(seq - (pos + ready)); {
+		case d == 0:
+			if counter.CompareAndSwap(pos, pos+1) {
+				return pos, true
+			}
+			pos = counter.Load()
+		case d < 0:
+			// The slot is still in use from the previous lap.
+			return 0, false
+		default:
+			// Another goroutine claimed pos; catch up.
+			pos = counter.Load()
+		}
+	}
+}
+
+// claimBatch is like claim, but reserves up to max consecutive positions
+// whose slots are all ready, and reports how many it reserved.
+func (r *MPMCRingBuffer[T]) claimBatch(counter *Uint64, ready uint64, max int) (uint64, int) {
+	if max > len(r.seqs) {
+		max = len(r.seqs)
+	}
+	for max > 0 {
+		pos := counter.Load()
+		n := 0
+		for ; n < max; n++ {
+			p := pos + uint64(n)
+			if int64(r.seqs[p&r.mask].Load()-(p+ready)) != 0 {
+				break
+			}
+		}
+		if n == 0 {
+			if int64(r.seqs[pos&r.mask].Load()-(pos+ready)) < 0 {
+				return 0, 0
+			}
+			continue // another goroutine claimed pos
+		}
+		if counter.CompareAndSwap(pos, pos+uint64(n)) {
+			return pos, n
+		}
+	}
+	return 0, 0
+}
+
+// Cap reports the capacity of the buffer.
+func (r *MPMCRingBuffer[T]) Cap() int {
+	return len(r.seqs)
+}
+
+// Len reports the number of values in the buffer.
+func (r *MPMCRingBuffer[T]) Len() int {
+	return ringLen(&r.head, &r.tail, len(r.seqs))
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingBuffer(t *testing.T) {
+	tests := []struct {
+		desc string
+		new  func(int) RingBuffer[int]
+	}{
+		{"SPSC", func(n int) RingBuffer[int] { return NewSPSCRingBuffer[int](n) }},
+		{"MPMC", func(n int) RingBuffer[int] { return NewMPMCRingBuffer[int](n) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r := tt.new(4)
+			assert.Equal(t, 4, r.Cap(), "Cap didn't match.")
+			_, ok := r.TryPop()
+			assert.False(t, ok, "TryPop of an empty buffer should fail.")
+
+			for i := 0; i < 4; i++ {
+				require.True(t, r.TryPush(i), "TryPush %d should succeed.", i)
+			}
+			assert.False(t, r.TryPush(4), "TryPush to a full buffer should fail.")
+			assert.Equal(t, 4, r.Len(), "Len didn't count every push.")
+
+			v, ok := r.TryPop()
+			require.True(t, ok, "TryPop of a full buffer should succeed.")
+			assert.Equal(t, 0, v, "TryPop didn't return values in FIFO order.")
+			assert.True(t, r.TryPush(4), "TryPush after TryPop should succeed.")
+
+			// Wrap around the end of the buffer a few times.
+			for i := 5; i < 20; i++ {
+				v, ok := r.TryPop()
+				require.True(t, ok, "TryPop should succeed.")
+				assert.Equal(t, i-4, v, "TryPop didn't return values in FIFO order.")
+				require.True(t, r.TryPush(i), "TryPush should succeed.")
+			}
+
+			out := make([]int, 6)
+			assert.Equal(t, 4, r.TryPopBatch(out), "TryPopBatch removed the wrong number of values.")
+			assert.Equal(t, []int{16, 17, 18, 19}, out[:4], "TryPopBatch didn't return values in FIFO order.")
+			assert.Equal(t, 0, r.Len(), "TryPopBatch didn't empty the buffer.")
+
+			assert.Equal(t, 4, r.TryPushBatch([]int{1, 2, 3, 4, 5}), "TryPushBatch added the wrong number of values.")
+			assert.Equal(t, 0, r.TryPushBatch([]int{6}), "TryPushBatch to a full buffer should add nothing.")
+			assert.Equal(t, 2, r.TryPopBatch(out[:2]), "TryPopBatch removed the wrong number of values.")
+			assert.Equal(t, []int{1, 2}, out[:2], "TryPopBatch didn't return values in FIFO order.")
+			assert.Equal(t, 2, r.Len(), "Len didn't account for batches.")
+		})
+	}
+
+	t.Run("panics", func(t *testing.T) {
+		for _, n := range []int{-1, 0, 1, 3, 12} {
+			assert.Panics(t, func() { NewSPSCRingBuffer[int](n) }, "Capacity %d should panic.", n)
+			assert.Panics(t, func() { NewMPMCRingBuffer[int](n) }, "Capacity %d should panic.", n)
+		}
+	})
+
+	t.Run("padding", func(t *testing.T) {
+		var r MPMCRingBuffer[int]
+		assert.True(t, unsafe.Offsetof(r.tail)-unsafe.Offsetof(r.head) >= _cacheLineSize,
+			"head and tail should be on different cache lines.")
+	})
+}
+
+// checkExactlyOnce verifies that every value in [0, n) was seen once.
+func checkExactlyOnce(t *testing.T, n int, seen [][]int) {
+	counts := make([]int, n)
+	for _, vs := range seen {
+		for _, v := range vs {
+			counts[v]++
+		}
+	}
+	for v, c := range counts {
+		if c != 1 {
+			t.Errorf("Value %d seen %d times, want exactly once.", v, c)
+		}
+	}
+}
+
+func TestSPSCRingBufferStress(t *testing.T) {
+	const n = 100000
+
+	r := NewSPSCRingBuffer[int](64)
+	go func() {
+		batch := make([]int, 0, 7)
+		for i := 0; i < n; {
+			if i%3 == 0 {
+				batch = batch[:0]
+				for j := i; j < n && len(batch) < cap(batch); j++ {
+					batch = append(batch, j)
+				}
+				i += r.TryPushBatch(batch)
+			} else if r.TryPush(i) {
+				i++
+			}
+			runtime.Gosched()
+		}
+	}()
+
+	var got []int
+	buf := make([]int, 5)
+	for len(got) < n {
+		k := r.TryPopBatch(buf)
+		for _, v := range buf[:k] {
+			require.Equal(t, len(got), v, "SPSC buffer reordered values.")
+			got = append(got, v)
+		}
+		if k == 0 {
+			runtime.Gosched()
+		}
+	}
+	checkExactlyOnce(t, n, [][]int{got})
+}
+
+func TestMPMCRingBufferStress(t *testing.T) {
+	const (
+		producers  = 4
+		consumers  = 4
+		iterations = 10000
+		n          = producers * iterations
+	)
+
+	var (
+		r        = NewMPMCRingBuffer[int](64)
+		received Int64
+		seen     = make([][]int, consumers)
+		wg       sync.WaitGroup
+	)
+
+	wg.Add(producers + consumers)
+	for p := 0; p < producers; p++ {
+		go func(p int) {
+			defer wg.Done()
+			batch := make([]int, 3)
+			for i := 0; i < iterations; {
+				if i%2 == 0 && i+len(batch) <= iterations {
+					for j := range batch {
+						batch[j] = p*iterations + i + j
+					}
+					k := r.TryPushBatch(batch)
+					// A partial batch leaves the rest for the next loop.
+					i += k
+				} else if r.TryPush(p*iterations + i) {
+					i++
+				}
+				if i%64 == 0 {
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+	for c := 0; c < consumers; c++ {
+		go func(c int) {
+			defer wg.Done()
+			buf := make([]int, 4)
+			for received.Load() < n {
+				var k int
+				if c%2 == 0 {
+					k = r.TryPopBatch(buf)
+				} else if v, ok := r.TryPop(); ok {
+					buf[0], k = v, 1
+				}
+				seen[c] = append(seen[c], buf[:k]...)
+				if received.Add(int64(k)); k == 0 {
+					runtime.Gosched()
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 0, r.Len(), "Buffer should be empty.")
+	checkExactlyOnce(t, n, seen)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// SeqLock holds a small multi-word value of type T inline, for values
+// that are read very often and written rarely.
+//
+// Writers are serialized by a mutex and bump a sequence number before and
+// after each write, so that it's odd while a write is in progress. Readers
+// never block: they copy the value and retry if the sequence number shows
+// that a write overlapped the copy. Unlike Value or Pointer, writes don't
+// allocate.
+//
+// Because a reader may copy a value while it's being written, T must not
+// contain pointers, including strings, slices, maps, and interfaces: a torn
+// copy of a pointer is unsafe even if it's discarded. Plain structs of
+// numbers, such as a position and a timestamp, are the intended use.
+// NewSeqLock, and Load and Update on first use, panic if T contains
+// pointers.
+//
+// Running under the race detector needs no option: builds with -race
+// switch Load to taking a read lock automatically. The detector can't be
+// told that a discarded torn copy is harmless, so it would report every
+// Load that overlaps a write; keying the switch off the race build tag
+// keeps those reports out without any setup, while the rest of the
+// program is still checked.
+//
+// The zero value holds the zero value of T and is ready to use.
+type SeqLock[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	mu      sync.RWMutex
+	seq     Uint64
+	checked Bool // whether T was checked for pointers
+	v       T
+}
+
+// NewSeqLock creates a new SeqLock holding val. It panics if T contains
+// pointers.
+func NewSeqLock[T any](val T) *SeqLock[T] {
+	s := &SeqLock[T]{v: val}
+	s.check()
+	return s
+}
+
+// check panics if T contains pointers. It inspects T only the first time.
+func (s *SeqLock[T]) check() {
+	if s.checked.Load() {
+		return
+	}
+	if typ := reflect.TypeOf((*T)(nil)).Elem(); hasPointers(typ) {
+		panic(fmt.Sprintf("atomic: SeqLock value type %v must not contain pointers", typ))
+	}
+	s.checked.Store(true)
+}
+
+// hasPointers reports whether values of typ contain pointers, including
+// those hidden in strings, slices, maps, channels, funcs, and interfaces.
+func hasPointers(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Array:
+		return typ.Len() > 0 && hasPointers(typ.Elem())
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if hasPointers(typ.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	case reflect.Ptr, reflect.UnsafePointer, reflect.String, reflect.Slice,
+		reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+// Load returns a consistent copy of the value. It retries until no write
+// overlaps its copy.
+func (s *SeqLock[T]) Load() T {
+	s.check()
+	if raceEnabled {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.v
+	}
+
+	for {
+		if seq := s.seq.Load(); seq&1 == 0 {
+			v := s.v
+			// Validate with a compare-and-swap that leaves seq unchanged
+			// rather than with a load: a load doesn't order the copy
+			// before the next write, so the Go memory model (and arm64
+			// in practice) would let a torn copy pass. The read-modify-write
+			// makes the next write's increment happen after the copy, at
+			// the cost of readers contending on seq's cache line.
+			if s.seq.CompareAndSwap(seq, seq) {
+				return v
+			}
+		}
+		// A write is in progress; let the writer finish.
+		runtime.Gosched()
+	}
+}
+
+// Store replaces the value.
+func (s *SeqLock[T]) Store(val T) {
+	s.Update(func(v *T) { *v = val })
+}
+
+// Update calls fn with a pointer to the value, which it may modify in
+// place. Readers won't see the value until fn returns. fn must not retain
+// the pointer or call other methods on the SeqLock.
+func (s *SeqLock[T]) Update(fn func(v *T)) {
+	s.check()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq.Inc()
+	defer s.seq.Inc()
+	fn(&s.v)
+}
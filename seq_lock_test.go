@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type seqLockPosition struct {
+	X, Y, Z float64
+	Time    int64
+}
+
+func TestSeqLock(t *testing.T) {
+	var zero SeqLock[seqLockPosition]
+	assert.Equal(t, seqLockPosition{}, zero.Load(), "Zero value should hold the zero value.")
+
+	s := NewSeqLock(seqLockPosition{X: 1, Time: 10})
+	assert.Equal(t, seqLockPosition{X: 1, Time: 10}, s.Load(), "Load didn't return the initial value.")
+
+	s.Store(seqLockPosition{Y: 2, Time: 20})
+	assert.Equal(t, seqLockPosition{Y: 2, Time: 20}, s.Load(), "Store didn't set the value.")
+
+	s.Update(func(p *seqLockPosition) { p.Z, p.Time = 3, 30 })
+	assert.Equal(t, seqLockPosition{Y: 2, Z: 3, Time: 30}, s.Load(), "Update didn't modify the value.")
+
+	assert.Panics(t, func() {
+		s.Update(func(*seqLockPosition) { panic("great sadness") })
+	}, "Update should propagate panics.")
+	assert.Equal(t, uint64(0), s.seq.Load()&1, "A panicking Update left a write in progress.")
+
+	t.Run("pointers", func(t *testing.T) {
+		const msg = "atomic: SeqLock value type string must not contain pointers"
+		var str SeqLock[string]
+		assert.PanicsWithValue(t, msg, func() { str.Load() }, "Load should reject strings.")
+		assert.PanicsWithValue(t, msg, func() { str.Store("foo") }, "Store should reject strings.")
+		assert.PanicsWithValue(t, msg, func() { NewSeqLock("foo") }, "NewSeqLock should reject strings.")
+
+		type withSlice struct {
+			N    int
+			Tags [2][]int
+		}
+		assert.Panics(t, func() { NewSeqLock(withSlice{}) }, "NewSeqLock should reject nested slices.")
+		assert.NotPanics(t, func() { NewSeqLock([0]*int{}) }, "NewSeqLock should accept empty arrays of pointers.")
+	})
+}
+
+func TestSeqLockTornReads(t *testing.T) {
+	const (
+		writers    = 2
+		readers    = 4
+		iterations = 2000
+	)
+
+	var (
+		s    SeqLock[seqLockPosition]
+		done Bool
+		wg   sync.WaitGroup
+		rg   sync.WaitGroup
+	)
+
+	wg.Add(writers)
+	for w := 0; w < writers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				f := float64(i)
+				s.Store(seqLockPosition{X: f, Y: f, Z: f, Time: int64(i)})
+			}
+		}()
+	}
+
+	rg.Add(readers)
+	for r := 0; r < readers; r++ {
+		go func() {
+			defer rg.Done()
+			for !done.Load() {
+				p := s.Load()
+				f := float64(p.Time)
+				if p.X != f || p.Y != f || p.Z != f {
+					t.Errorf("Load returned a torn value: %+v", p)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	done.Store(true)
+	rg.Wait()
+}
+
+func BenchmarkSeqLock(b *testing.B) {
+	val := seqLockPosition{X: 1, Y: 2, Z: 3, Time: 4}
+
+	b.Run("SeqLock", func(b *testing.B) {
+		s := NewSeqLock(val)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%100 == 0 {
+					s.Store(val)
+				} else {
+					s.Load()
+				}
+			}
+		})
+	})
+
+	b.Run("RWMutex", func(b *testing.B) {
+		var (
+			mu sync.RWMutex
+			v  = val
+		)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%100 == 0 {
+					mu.Lock()
+					v = val
+					mu.Unlock()
+				} else {
+					mu.RLock()
+					_ = v
+					mu.RUnlock()
+				}
+			}
+		})
+	})
+
+	b.Run("Pointer", func(b *testing.B) {
+		p := NewPointer(&val)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%100 == 0 {
+					v := val
+					p.Store(&v)
+				} else {
+					_ = *p.Load()
+				}
+			}
+		})
+	})
+}
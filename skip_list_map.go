@@ -0,0 +1,332 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.21
+// +build go1.21
+
+package atomic
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+const _skipListMaxLevel = 32
+
+// SkipListMap is a lock-free sorted map, after the lock-free skiplist of
+// Herlihy and Shavit.
+//
+// Every node holds its value behind an atomic pointer, and a nil value
+// means the node has been deleted. Delete first clears the value, which is
+// when the key stops being visible, then marks the node's links from the
+// top level down and unlinks it. Links are immutable (node, marked) pairs
+// swapped with compare-and-swap, so nothing can be linked after a marked
+// node. Goroutines that run into marked nodes unlink them on the way.
+//
+// Iteration is weakly consistent: Range and Ascend see keys in ascending
+// order and never see a key twice, but may or may not see writes made
+// while they run.
+//
+// The zero value is an empty map ready to use.
+type SkipListMap[K cmp.Ordered, V any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	head [_skipListMaxLevel]Pointer[skipRef[K, V]]
+	seed Uint64
+	len  Int64
+}
+
+type skipNode[K cmp.Ordered, V any] struct {
+	key  K
+	val  Pointer[V] // nil once deleted
+	next []Pointer[skipRef[K, V]]
+}
+
+// skipRef is an immutable link to the next node. A nil *skipRef is an
+// unmarked link to nothing.
+type skipRef[K cmp.Ordered, V any] struct {
+	node   *skipNode[K, V]
+	marked bool
+}
+
+func (r *skipRef[K, V]) get() (*skipNode[K, V], bool) {
+	if r == nil {
+		return nil, false
+	}
+	return r.node, r.marked
+}
+
+// skipPath records, for each level, the tower that links to the position
+// for a key, the link it held, and the node after that position.
+type skipPath[K cmp.Ordered, V any] struct {
+	preds    [_skipListMaxLevel][]Pointer[skipRef[K, V]]
+	predRefs [_skipListMaxLevel]*skipRef[K, V]
+	succs    [_skipListMaxLevel]*skipNode[K, V]
+}
+
+// find fills path with the position for key at every level, unlinking any
+// marked nodes it passes, and returns the node holding key, if any.
+func (m *SkipListMap[K, V]) find(key K, path *skipPath[K, V]) *skipNode[K, V] {
+retry:
+	pred := m.head[:]
+	for l := _skipListMaxLevel - 1; l >= 0; l-- {
+		predRef := pred[l].Load()
+		curr, marked := predRef.get()
+		if marked {
+			// pred was deleted under us.
+			goto retry
+		}
+		for curr != nil {
+			currRef := curr.next[l].Load()
+			succ, marked := currRef.get()
+			if marked {
+				next := &skipRef[K, V]{node: succ}
+				if !pred[l].CompareAndSwap(predRef, next) {
+					goto retry
+				}
+				predRef, curr = next, succ
+				continue
+			}
+			if curr.key >= key {
+				break
+			}
+			pred, predRef, curr = curr.next, currRef, succ
+		}
+		path.preds[l] = pred
+		path.predRefs[l] = predRef
+		path.succs[l] = curr
+	}
+	if n := path.succs[0]; n != nil && n.key == key {
+		return n
+	}
+	return nil
+}
+
+// seek returns the first node with a key of at least key, without
+// modifying the list. It passes over marked nodes rather than unlinking
+// them, so it never retries.
+func (m *SkipListMap[K, V]) seek(key K) *skipNode[K, V] {
+	pred := m.head[:]
+	var curr *skipNode[K, V]
+	for l := _skipListMaxLevel - 1; l >= 0; l-- {
+		curr, _ = pred[l].Load().get()
+		for curr != nil && curr.key < key {
+			pred = curr.next
+			curr, _ = pred[l].Load().get()
+		}
+	}
+	return curr
+}
+
+// lastBefore returns the last node with a key less than bound, or the last
+// node of all if bound is nil.
+func (m *SkipListMap[K, V]) lastBefore(bound *K) *skipNode[K, V] {
+	pred := m.head[:]
+	var last *skipNode[K, V]
+	for l := _skipListMaxLevel - 1; l >= 0; l-- {
+		for {
+			next, _ := pred[l].Load().get()
+			if next == nil || (bound != nil && next.key >= *bound) {
+				break
+			}
+			last, pred = next, next.next
+		}
+	}
+	return last
+}
+
+func (m *SkipListMap[K, V]) randomLevel() int {
+	// Each level is half as likely as the one below it.
+	x := splitmix64(m.seed.Add(1))
+	return bits.TrailingZeros64(x|1<<(_skipListMaxLevel-1)) + 1
+}
+
+// mark marks every link out of n, top level first, so that no node can be
+// linked after it. It's safe to call more than once.
+func (m *SkipListMap[K, V]) mark(n *skipNode[K, V]) {
+	for l := len(n.next) - 1; l >= 0; l-- {
+		for {
+			ref := n.next[l].Load()
+			succ, marked := ref.get()
+			if marked || n.next[l].CompareAndSwap(ref, &skipRef[K, V]{node: succ, marked: true}) {
+				break
+			}
+		}
+	}
+}
+
+// Get returns the value stored for key, if any.
+func (m *SkipListMap[K, V]) Get(key K) (value V, ok bool) {
+	if n := m.seek(key); n != nil && n.key == key {
+		if v := n.val.Load(); v != nil {
+			return *v, true
+		}
+	}
+	return value, false
+}
+
+// Put sets the value for key.
+func (m *SkipListMap[K, V]) Put(key K, value V) {
+	var path skipPath[K, V]
+	for {
+		if n := m.find(key, &path); n != nil {
+			if m.replace(n, &value) {
+				return
+			}
+			// n is being deleted; find will unlink it.
+			continue
+		}
+
+		n := &skipNode[K, V]{key: key, next: make([]Pointer[skipRef[K, V]], m.randomLevel())}
+		n.val.Store(&value)
+		for l := range n.next {
+			n.next[l].Store(&skipRef[K, V]{node: path.succs[l]})
+		}
+		// Linking the bottom level is what adds key to the map.
+		if !path.preds[0][0].CompareAndSwap(path.predRefs[0], &skipRef[K, V]{node: n}) {
+			continue
+		}
+		m.len.Inc()
+		m.linkUpper(n, &path)
+		return
+	}
+}
+
+// linkUpper links n into the levels above the bottom. The upper levels only
+// speed up searches, so it gives up if n is deleted meanwhile.
+func (m *SkipListMap[K, V]) linkUpper(n *skipNode[K, V], path *skipPath[K, V]) {
+	for l := 1; l < len(n.next); l++ {
+		for {
+			ref := n.next[l].Load()
+			succ, marked := ref.get()
+			if marked {
+				return
+			}
+			if succ != path.succs[l] {
+				if !n.next[l].CompareAndSwap(ref, &skipRef[K, V]{node: path.succs[l]}) {
+					continue
+				}
+			}
+			if path.preds[l][l].CompareAndSwap(path.predRefs[l], &skipRef[K, V]{node: n}) {
+				break
+			}
+			if m.find(n.key, path) != n {
+				return
+			}
+		}
+	}
+}
+
+// replace swaps in a new value for n, and reports false if n has been
+// deleted.
+func (m *SkipListMap[K, V]) replace(n *skipNode[K, V], value *V) bool {
+	for {
+		cur := n.val.Load()
+		if cur == nil {
+			// Help the deleting goroutine so that we don't wait on it.
+			m.mark(n)
+			return false
+		}
+		if n.val.CompareAndSwap(cur, value) {
+			return true
+		}
+	}
+}
+
+// Delete removes key from the map and returns its value, if it was
+// present.
+func (m *SkipListMap[K, V]) Delete(key K) (value V, ok bool) {
+	var path skipPath[K, V]
+	n := m.find(key, &path)
+	if n == nil {
+		return value, false
+	}
+	for {
+		cur := n.val.Load()
+		if cur == nil {
+			return value, false
+		}
+		if n.val.CompareAndSwap(cur, nil) {
+			m.len.Dec()
+			m.mark(n)
+			m.find(key, &path) // unlinks n
+			return *cur, true
+		}
+	}
+}
+
+// First returns the smallest key in the map and its value. It reports
+// false if the map is empty.
+func (m *SkipListMap[K, V]) First() (key K, value V, ok bool) {
+	m.Range(func(k K, v V) bool {
+		key, value, ok = k, v, true
+		return false
+	})
+	return key, value, ok
+}
+
+// Last returns the largest key in the map and its value. It reports false
+// if the map is empty.
+func (m *SkipListMap[K, V]) Last() (key K, value V, ok bool) {
+	var bound *K
+	for {
+		n := m.lastBefore(bound)
+		if n == nil {
+			return key, value, false
+		}
+		if v := n.val.Load(); v != nil {
+			return n.key, *v, true
+		}
+		// n was deleted; look for its predecessor.
+		bound = &n.key
+	}
+}
+
+// Range calls fn for each key and value in the map in ascending key order
+// until fn returns false.
+func (m *SkipListMap[K, V]) Range(fn func(key K, value V) bool) {
+	n, _ := m.head[0].Load().get()
+	m.ascend(n, fn)
+}
+
+// Ascend calls fn for each key of at least from and its value in ascending
+// key order until fn returns false.
+func (m *SkipListMap[K, V]) Ascend(from K, fn func(key K, value V) bool) {
+	m.ascend(m.seek(from), fn)
+}
+
+func (m *SkipListMap[K, V]) ascend(n *skipNode[K, V], fn func(K, V) bool) {
+	for ; n != nil; n, _ = n.next[0].Load().get() {
+		if v := n.val.Load(); v != nil {
+			if !fn(n.key, *v) {
+				return
+			}
+		}
+	}
+}
+
+// Len reports the number of entries in the map. Under concurrent use it's
+// only an approximation.
+func (m *SkipListMap[K, V]) Len() int {
+	if n := m.len.Load(); n > 0 {
+		return int(n)
+	}
+	return 0
+}
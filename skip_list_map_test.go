@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.21
+// +build go1.21
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectSkipList[K int | string, V any](m *SkipListMap[K, V]) []K {
+	var keys []K
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+func TestSkipListMap(t *testing.T) {
+	var m SkipListMap[int, string]
+	_, _, ok := m.First()
+	assert.False(t, ok, "First of an empty map should report false.")
+	_, _, ok = m.Last()
+	assert.False(t, ok, "Last of an empty map should report false.")
+	_, ok = m.Delete(1)
+	assert.False(t, ok, "Delete of a missing key should report false.")
+
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		m.Put(k, "v")
+	}
+	m.Put(30, "thirty")
+	assert.Equal(t, 5, m.Len(), "Len didn't count every new key.")
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, collectSkipList(&m), "Range isn't in key order.")
+
+	v, ok := m.Get(30)
+	require.True(t, ok, "Get of a stored key should report true.")
+	assert.Equal(t, "thirty", v, "Put didn't replace the value.")
+	_, ok = m.Get(35)
+	assert.False(t, ok, "Get of a missing key should report false.")
+
+	v, ok = m.Delete(30)
+	require.True(t, ok, "Delete of a stored key should report true.")
+	assert.Equal(t, "thirty", v, "Delete returned the wrong value.")
+	_, ok = m.Get(30)
+	assert.False(t, ok, "Delete didn't remove the key.")
+	assert.Equal(t, 4, m.Len(), "Len didn't account for Delete.")
+
+	var from []int
+	m.Ascend(25, func(k int, _ string) bool {
+		from = append(from, k)
+		return k < 40
+	})
+	assert.Equal(t, []int{40}, from[:1], "Ascend didn't start at the first key after from.")
+	assert.Len(t, from, 1, "Ascend didn't stop when fn returned false.")
+
+	k, _, ok := m.First()
+	assert.True(t, ok && k == 10, "First returned %v.", k)
+	k, _, ok = m.Last()
+	assert.True(t, ok && k == 50, "Last returned %v.", k)
+	m.Delete(50)
+	k, _, _ = m.Last()
+	assert.Equal(t, 40, k, "Last didn't skip the deleted key.")
+
+	t.Run("many keys", func(t *testing.T) {
+		var m SkipListMap[int, int]
+		for i := 0; i < 1000; i++ {
+			m.Put((i*7919)%1000, i)
+		}
+		keys := collectSkipList(&m)
+		require.Len(t, keys, 1000, "Range didn't visit every key.")
+		for i, k := range keys {
+			require.Equal(t, i, k, "Range isn't in key order.")
+		}
+		for i := 0; i < 1000; i += 2 {
+			m.Delete(i)
+		}
+		assert.Equal(t, 500, m.Len(), "Len didn't account for Delete.")
+		k, _, _ := m.Last()
+		assert.Equal(t, 999, k, "Last returned the wrong key.")
+	})
+}
+
+func TestSkipListMapStress(t *testing.T) {
+	const (
+		writers    = 4
+		readers    = 2
+		keys       = 256
+		iterations = 2000
+	)
+
+	var (
+		m    SkipListMap[int, int]
+		wg   sync.WaitGroup
+		done Bool
+	)
+
+	wg.Add(writers)
+	for w := 0; w < writers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				k := int(splitmix64(uint64(w*iterations+i)) % keys)
+				if i%3 == 0 {
+					m.Delete(k)
+				} else {
+					m.Put(k, k*10)
+				}
+			}
+			// Leave one key per writer behind to check the final state.
+			m.Put(keys+w, w)
+		}(w)
+	}
+
+	var rg sync.WaitGroup
+	rg.Add(readers)
+	for r := 0; r < readers; r++ {
+		go func() {
+			defer rg.Done()
+			for !done.Load() {
+				prev := -1
+				m.Range(func(k, v int) bool {
+					assert.True(t, k > prev, "Range saw %d after %d.", k, prev)
+					if k < keys {
+						assert.Equal(t, k*10, v, "Range saw the wrong value.")
+					}
+					prev = k
+					return true
+				})
+				if first, _, ok := m.First(); ok {
+					assert.True(t, first >= 0, "First returned an impossible key.")
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	done.Store(true)
+	rg.Wait()
+
+	got := collectSkipList(&m)
+	assert.Equal(t, len(got), m.Len(), "Len disagreed with Range after writes finished.")
+	for w := 0; w < writers; w++ {
+		v, ok := m.Get(keys + w)
+		assert.True(t, ok && v == w, "Lost the final write from writer %d.", w)
+	}
+	for l := 0; l < _skipListMaxLevel; l++ {
+		// Every level must be sorted, including nodes not yet unlinked.
+		prev := -1
+		for n, _ := m.head[l].Load().get(); n != nil; n, _ = n.next[l].Load().get() {
+			require.True(t, n.key > prev, "Level %d saw %d after %d.", l, n.key, prev)
+			prev = n.key
+		}
+	}
+}
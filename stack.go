@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+// Stack is a lock-free LIFO stack (a Treiber stack).
+//
+// Push and Pop each allocate or release one node and retry a single
+// compare-and-swap on the top of the stack until it succeeds.
+//
+// The classic weakness of Treiber stacks is the ABA problem: a Pop reads
+// the top node A and its successor, another goroutine pops A and pushes it
+// back, and the first Pop's compare-and-swap succeeds even though A's
+// successor has changed. That requires A's memory to be reused while the
+// first goroutine still holds a pointer to it. Under Go's garbage collector
+// that can't happen: every Push allocates a fresh node, and a node isn't
+// freed, let alone reused, while any goroutine still references it. So a
+// successful compare-and-swap always means the top is still the same node,
+// and nodes are never modified once pushed.
+//
+// The zero value is an empty stack ready to use.
+type Stack[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	// len comes first so that it's 8-byte aligned on 32-bit platforms.
+	len Int64
+	top Pointer[stackNode[T]]
+}
+
+type stackNode[T any] struct {
+	value T
+	next  *stackNode[T]
+}
+
+// Push adds value to the top of the stack.
+func (s *Stack[T]) Push(value T) {
+	n := &stackNode[T]{value: value}
+	for {
+		n.next = s.top.Load()
+		if s.top.CompareAndSwap(n.next, n) {
+			break
+		}
+	}
+	s.len.Inc()
+}
+
+// Pop removes and returns the value at the top of the stack. It reports
+// false if the stack is empty.
+func (s *Stack[T]) Pop() (value T, ok bool) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			return value, false
+		}
+		if s.top.CompareAndSwap(top, top.next) {
+			s.len.Dec()
+			return top.value, true
+		}
+	}
+}
+
+// Peek returns the value at the top of the stack without removing it. It
+// reports false if the stack is empty.
+func (s *Stack[T]) Peek() (value T, ok bool) {
+	if top := s.top.Load(); top != nil {
+		return top.value, true
+	}
+	return value, false
+}
+
+// PopAll atomically empties the stack and returns its contents, top first.
+func (s *Stack[T]) PopAll() []T {
+	var values []T
+	for n := s.top.Swap(nil); n != nil; n = n.next {
+		values = append(values, n.value)
+	}
+	s.len.Sub(int64(len(values)))
+	return values
+}
+
+// Len reports the number of values on the stack.
+//
+// The count is maintained separately from the stack itself, so while
+// other goroutines are pushing and popping it's only an approximation.
+func (s *Stack[T]) Len() int {
+	if n := s.len.Load(); n > 0 {
+		return int(n)
+	}
+	// Pop may briefly run ahead of the Push it's popping.
+	return 0
+}
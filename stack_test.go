@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStack(t *testing.T) {
+	var s Stack[string]
+	_, ok := s.Pop()
+	assert.False(t, ok, "Pop of an empty stack should report false.")
+	_, ok = s.Peek()
+	assert.False(t, ok, "Peek of an empty stack should report false.")
+	assert.Empty(t, s.PopAll(), "PopAll of an empty stack should return nothing.")
+
+	s.Push("foo")
+	s.Push("bar")
+	s.Push("baz")
+	assert.Equal(t, 3, s.Len(), "Len didn't count every Push.")
+
+	v, ok := s.Peek()
+	require.True(t, ok, "Peek of a non-empty stack should report true.")
+	assert.Equal(t, "baz", v, "Peek didn't return the top value.")
+
+	v, ok = s.Pop()
+	require.True(t, ok, "Pop of a non-empty stack should report true.")
+	assert.Equal(t, "baz", v, "Pop didn't return the top value.")
+	assert.Equal(t, 2, s.Len(), "Len didn't account for Pop.")
+
+	s.Push("qux")
+	assert.Equal(t, []string{"qux", "bar", "foo"}, s.PopAll(), "PopAll didn't return values top first.")
+	assert.Equal(t, 0, s.Len(), "PopAll didn't empty the stack.")
+	_, ok = s.Pop()
+	assert.False(t, ok, "PopAll didn't empty the stack.")
+}
+
+func TestStackConcurrent(t *testing.T) {
+	const (
+		producers  = 4
+		consumers  = 4
+		iterations = 1000
+	)
+
+	var (
+		s       Stack[int]
+		popped  = make([][]int, consumers)
+		pushers sync.WaitGroup
+		poppers sync.WaitGroup
+		done    Bool
+	)
+
+	pushers.Add(producers)
+	for p := 0; p < producers; p++ {
+		go func(p int) {
+			defer pushers.Done()
+			for i := 0; i < iterations; i++ {
+				s.Push(p*iterations + i)
+			}
+		}(p)
+	}
+
+	poppers.Add(consumers)
+	for c := 0; c < consumers; c++ {
+		go func(c int) {
+			defer poppers.Done()
+			for {
+				if v, ok := s.Pop(); ok {
+					popped[c] = append(popped[c], v)
+				} else if done.Load() {
+					return
+				}
+			}
+		}(c)
+	}
+
+	pushers.Wait()
+	done.Store(true)
+	poppers.Wait()
+
+	seen := make(map[int]int)
+	for _, vs := range popped {
+		for _, v := range vs {
+			seen[v]++
+		}
+	}
+	for _, v := range s.PopAll() {
+		seen[v]++
+	}
+	require.Len(t, seen, producers*iterations, "Lost values pushed concurrently.")
+	for v, n := range seen {
+		assert.Equal(t, 1, n, "Value %d popped more than once.", v)
+	}
+}
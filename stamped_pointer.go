@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+// StampedPointer is an atomic pair of a pointer and a version stamp.
+//
+// Pointer.CompareAndSwap only compares addresses, so it can't tell a node
+// that was removed, recycled (for example through a sync.Pool), and
+// reinserted apart from the node it saw originally: the ABA problem.
+// Bumping the stamp on every change makes such a CompareAndSwap fail.
+//
+// The pair is kept in an immutable, heap-allocated box that is replaced on
+// every change, so the garbage collector sees the pointer as usual. Each
+// change allocates a new box.
+//
+// The zero value holds a nil pointer and stamp 0.
+type StampedPointer[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Pointer[stampedRef[T]]
+}
+
+type stampedRef[T any] struct {
+	ptr   *T
+	stamp uint64
+}
+
+// NewStampedPointer creates a new StampedPointer holding ptr and stamp.
+func NewStampedPointer[T any](ptr *T, stamp uint64) *StampedPointer[T] {
+	var p StampedPointer[T]
+	p.Store(ptr, stamp)
+	return &p
+}
+
+// Load atomically loads the pointer and its stamp.
+func (p *StampedPointer[T]) Load() (ptr *T, stamp uint64) {
+	if ref := p.v.Load(); ref != nil {
+		return ref.ptr, ref.stamp
+	}
+	return nil, 0
+}
+
+// Store atomically stores ptr and stamp.
+func (p *StampedPointer[T]) Store(ptr *T, stamp uint64) {
+	p.v.Store(&stampedRef[T]{ptr: ptr, stamp: stamp})
+}
+
+// CompareAndSwap atomically replaces the pair with newPtr and newStamp if
+// it currently holds oldPtr and oldStamp. It reports whether it did.
+func (p *StampedPointer[T]) CompareAndSwap(oldPtr *T, oldStamp uint64, newPtr *T, newStamp uint64) (swapped bool) {
+	return casBox(&p.v, func(ref stampedRef[T]) bool {
+		return ref.ptr == oldPtr && ref.stamp == oldStamp
+	}, stampedRef[T]{ptr: newPtr, stamp: newStamp})
+}
+
+// AttemptStamp atomically sets the stamp to newStamp if the pointer is
+// currently expectedPtr, whatever its stamp. It reports whether it did.
+func (p *StampedPointer[T]) AttemptStamp(expectedPtr *T, newStamp uint64) bool {
+	return casBox(&p.v, func(ref stampedRef[T]) bool {
+		return ref.ptr == expectedPtr
+	}, stampedRef[T]{ptr: expectedPtr, stamp: newStamp})
+}
+
+// casBox atomically replaces the immutable box held by p with one holding
+// next if match accepts the boxed value, treating a nil box as the zero
+// value. It reports whether match accepted the value.
+//
+// Boxes are compared by address, so a concurrent Store of an equal value
+// makes the compare-and-swap fail; casBox retries in that case rather than
+// report a mismatch that didn't happen.
+func casBox[B comparable](p *Pointer[B], match func(B) bool, next B) bool {
+	for {
+		cur := p.Load()
+		var curVal B
+		if cur != nil {
+			curVal = *cur
+		}
+		if !match(curVal) {
+			return false
+		}
+		if cur != nil && curVal == next {
+			return true
+		}
+		if p.CompareAndSwap(cur, &next) {
+			return true
+		}
+	}
+}
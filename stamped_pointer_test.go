@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStampedPointer(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var p StampedPointer[int]
+		ptr, stamp := p.Load()
+		assert.Nil(t, ptr, "Zero value should hold a nil pointer.")
+		assert.Equal(t, uint64(0), stamp, "Zero value should hold stamp 0.")
+
+		x := new(int)
+		assert.False(t, p.CompareAndSwap(nil, 1, x, 2), "CompareAndSwap succeeded with the wrong stamp.")
+		require.True(t, p.CompareAndSwap(nil, 0, x, 1), "CompareAndSwap on the zero value failed.")
+		ptr, stamp = p.Load()
+		assert.True(t, ptr == x, "CompareAndSwap didn't store the pointer.")
+		assert.Equal(t, uint64(1), stamp, "CompareAndSwap didn't store the stamp.")
+	})
+
+	a, b := new(int), new(int)
+	p := NewStampedPointer(a, 7)
+
+	ptr, stamp := p.Load()
+	assert.True(t, ptr == a, "NewStampedPointer didn't store the pointer.")
+	assert.Equal(t, uint64(7), stamp, "NewStampedPointer didn't store the stamp.")
+
+	assert.True(t, p.CompareAndSwap(a, 7, a, 7), "CompareAndSwap to the same pair failed.")
+
+	t.Run("ABA", func(t *testing.T) {
+		// Another goroutine changes a to b and back, bumping the stamp.
+		require.True(t, p.CompareAndSwap(a, 7, b, 8), "CompareAndSwap failed.")
+		require.True(t, p.CompareAndSwap(b, 8, a, 9), "CompareAndSwap failed.")
+
+		assert.False(t, p.CompareAndSwap(a, 7, b, 8), "CompareAndSwap succeeded with a stale stamp.")
+		ptr, stamp := p.Load()
+		assert.True(t, ptr == a, "Failed CompareAndSwap changed the pointer.")
+		assert.Equal(t, uint64(9), stamp, "Failed CompareAndSwap changed the stamp.")
+	})
+
+	t.Run("AttemptStamp", func(t *testing.T) {
+		assert.False(t, p.AttemptStamp(b, 20), "AttemptStamp succeeded with the wrong pointer.")
+		require.True(t, p.AttemptStamp(a, 20), "AttemptStamp failed.")
+		ptr, stamp := p.Load()
+		assert.True(t, ptr == a, "AttemptStamp changed the pointer.")
+		assert.Equal(t, uint64(20), stamp, "AttemptStamp didn't set the stamp.")
+	})
+
+	t.Run("Store", func(t *testing.T) {
+		p.Store(b, 3)
+		ptr, stamp := p.Load()
+		assert.True(t, ptr == b, "Store didn't set the pointer.")
+		assert.Equal(t, uint64(3), stamp, "Store didn't set the stamp.")
+
+		// A concurrent Store of an equal pair doesn't make CompareAndSwap fail.
+		p.Store(b, 3)
+		assert.True(t, p.CompareAndSwap(b, 3, a, 4), "CompareAndSwap failed after storing an equal pair.")
+	})
+}
+
+func TestStampedPointerConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 1000
+	)
+
+	nodes := []*int{new(int), new(int)}
+	p := NewStampedPointer(nodes[0], 0)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				for {
+					ptr, stamp := p.Load()
+					// Alternate between two nodes so addresses repeat.
+					next := nodes[(stamp+1)%2]
+					if p.CompareAndSwap(ptr, stamp, next, stamp+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	ptr, stamp := p.Load()
+	assert.Equal(t, uint64(goroutines*iterations), stamp, "Lost concurrent updates.")
+	assert.True(t, ptr == nodes[stamp%2], "Pointer doesn't match the stamp.")
+}
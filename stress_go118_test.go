@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+func init() {
+	_stressTests["stack"] = stressStack
+	_stressTests["ringbuffer/mpmc"] = stressMPMCRingBuffer
+}
+
+func stressStack() func() {
+	var s Stack[int]
+	return func() {
+		s.Push(1)
+		s.Push(2)
+		s.Peek()
+		s.Pop()
+		s.Len()
+		s.Pop()
+		s.Push(3)
+		s.PopAll()
+	}
+}
+
+func stressMPMCRingBuffer() func() {
+	r := NewMPMCRingBuffer[int](8)
+	return func() {
+		var buf [2]int
+		r.TryPush(1)
+		r.TryPushBatch([]int{2, 3})
+		r.Len()
+		r.TryPop()
+		r.TryPopBatch(buf[:])
+	}
+}
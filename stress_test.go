@@ -50,6 +50,7 @@ var _stressTests = map[string]func() func(){
 	"duration": stressDuration,
 	"error":    stressError,
 	"time":     stressTime,
+	"pair32":   stressPair32,
 }
 
 func TestStress(t *testing.T) {
@@ -287,3 +288,15 @@ func stressTime() func() {
 		atom.Store(time.Time{})
 	}
 }
+
+func stressPair32() func() {
+	var atom = NewPair32(0, 0)
+	return func() {
+		atom.Load()
+		atom.AddA(1)
+		atom.AddB(math.MaxUint32)
+		atom.CompareAndSwap(1, 0, 0, 1)
+		atom.Swap(5, 6)
+		atom.Store(1, 0)
+	}
+}
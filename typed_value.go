@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TypedValue is an atomic type-safe wrapper for values of type T.
+//
+// Unlike Value, TypedValue may store nil when T is an interface type, and
+// may store values of differing concrete types behind an interface T. The
+// zero value is ready to use and holds the zero value of T.
+type TypedValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value
+}
+
+// atomic.Value panics on nil inputs, or if the underlying type changes.
+// Stabilize by always storing a packedValue. Error uses packedValue[error]
+// too; packedError only remains for Go versions without generics.
+type packedValue[T any] struct{ Value T }
+
+func unpackValue[T any](v interface{}) T {
+	if p, ok := v.(packedValue[T]); ok {
+		return p.Value
+	}
+	var zero T
+	return zero
+}
+
+// NewTypedValue creates a new TypedValue.
+func NewTypedValue[T any](val T) *TypedValue[T] {
+	x := &TypedValue[T]{}
+	x.Store(val)
+	return x
+}
+
+// Load atomically loads the wrapped value.
+func (x *TypedValue[T]) Load() T {
+	return unpackValue[T](x.v.Load())
+}
+
+// Store atomically stores the passed value.
+func (x *TypedValue[T]) Store(val T) {
+	x.v.Store(packedValue[T]{val})
+}
+
+// Swap atomically stores the given value and returns the old value.
+func (x *TypedValue[T]) Swap(val T) (old T) {
+	return unpackValue[T](x.v.Swap(packedValue[T]{val}))
+}
+
+// CompareAndSwap is an atomic compare-and-swap for values of type T.
+//
+// Like sync/atomic.Value, CompareAndSwap panics if old is not comparable, so
+// it may only be used when T is comparable or when T is an interface holding
+// comparable values.
+func (x *TypedValue[T]) CompareAndSwap(old, new T) (swapped bool) {
+	if x.v.CompareAndSwap(packedValue[T]{old}, packedValue[T]{new}) {
+		return true
+	}
+
+	var zero T
+	if interface{}(packedValue[T]{old}) == interface{}(packedValue[T]{zero}) {
+		// If the old value is the zero value, then it's possible the
+		// underlying Value hasn't been set and is nil, so retry with nil.
+		return x.v.CompareAndSwap(nil, packedValue[T]{new})
+	}
+
+	return false
+}
+
+// String returns a human readable representation of the wrapped value.
+func (x *TypedValue[T]) String() string {
+	return fmt.Sprint(x.Load())
+}
+
+// MarshalJSON encodes the wrapped value into JSON.
+func (x *TypedValue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.Load())
+}
+
+// UnmarshalJSON decodes JSON into the wrapped value.
+func (x *TypedValue[T]) UnmarshalJSON(b []byte) error {
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	x.Store(v)
+	return nil
+}
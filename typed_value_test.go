@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stringerFunc func() string
+
+func (f stringerFunc) String() string { return f() }
+
+type stringerString string
+
+func (s stringerString) String() string { return string(s) }
+
+func TestTypedValue(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var v TypedValue[int]
+		assert.Equal(t, 0, v.Load(), "Initial value should be the zero value.")
+		assert.True(t, v.CompareAndSwap(0, 42), "CAS from the zero value didn't report a swap.")
+		assert.Equal(t, 42, v.Load(), "CAS didn't set the correct value.")
+	})
+
+	t.Run("zero value Swap", func(t *testing.T) {
+		var v TypedValue[string]
+		assert.Equal(t, "", v.Swap("foo"), "Swap didn't return the zero value.")
+		assert.Equal(t, "foo", v.Load(), "Swap didn't set the correct value.")
+	})
+
+	v := NewTypedValue(1)
+	require.Equal(t, 1, v.Load(), "Load didn't return the initial value.")
+
+	v.Store(2)
+	require.Equal(t, 2, v.Load(), "Store didn't set the correct value.")
+
+	require.Equal(t, 2, v.Swap(3), "Swap didn't return the old value.")
+	require.Equal(t, 3, v.Load(), "Swap didn't set the correct value.")
+
+	require.False(t, v.CompareAndSwap(2, 4), "CAS of the wrong value reported a swap.")
+	require.True(t, v.CompareAndSwap(3, 4), "CAS didn't report a swap.")
+	require.Equal(t, 4, v.Load(), "CAS didn't set the correct value.")
+
+	require.Equal(t, "4", v.String(), "String() returned an unexpected value.")
+
+	t.Run("JSON/Marshal", func(t *testing.T) {
+		bytes, err := json.Marshal(v)
+		require.NoError(t, err, "json.Marshal errored unexpectedly.")
+		require.Equal(t, []byte("4"), bytes, "json.Marshal encoded the wrong bytes.")
+	})
+
+	t.Run("JSON/Unmarshal", func(t *testing.T) {
+		err := json.Unmarshal([]byte("40"), &v)
+		require.NoError(t, err, "json.Unmarshal errored unexpectedly.")
+		require.Equal(t, 40, v.Load(), "json.Unmarshal didn't set the correct value.")
+	})
+
+	t.Run("JSON/Unmarshal/Error", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`"40"`), &v)
+		require.Error(t, err, "json.Unmarshal didn't error as expected.")
+		assertErrorJSONUnmarshalType(t, err,
+			"json.Unmarshal failed with unexpected error %v, want UnmarshalTypeError.", err)
+	})
+}
+
+func TestTypedValueInterface(t *testing.T) {
+	var v TypedValue[error]
+	assert.Nil(t, v.Load(), "Initial value should be nil.")
+
+	err1 := errors.New("great sadness")
+	v.Store(err1)
+	assert.Equal(t, err1, v.Load(), "Store didn't set the correct value.")
+
+	// Values of differing concrete types can be stored behind the interface.
+	err2 := fmt.Errorf("wrapped: %w", io.EOF)
+	assert.Equal(t, err1, v.Swap(err2), "Swap didn't return the old value.")
+	assert.Equal(t, err2, v.Load(), "Swap didn't set the correct value.")
+
+	assert.True(t, v.CompareAndSwap(err2, nil), "CAS to nil didn't report a swap.")
+	assert.Nil(t, v.Load(), "CAS didn't store nil.")
+
+	v.Store(io.EOF)
+	v.Store(nil)
+	assert.Nil(t, v.Load(), "Store of nil didn't work.")
+	assert.True(t, v.CompareAndSwap(nil, io.EOF), "CAS from nil didn't report a swap.")
+
+	t.Run("uncomparable", func(t *testing.T) {
+		fn := stringerFunc(func() string { return "foo" })
+		v := NewTypedValue[fmt.Stringer](stringerString("foo"))
+		v.Store(fn)
+		assert.Equal(t, "foo", v.Load().String(), "Load didn't return the stored value.")
+		assert.Panics(t, func() { v.CompareAndSwap(fn, nil) },
+			"CAS of uncomparable values should panic.")
+	})
+}
@@ -0,0 +1,140 @@
+// @generated Code generated by gen-atomicarray.
+
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Uint64Array is a fixed-length array of uint64s with atomic access to each
+// element. Bulk operations such as AddVector and Snapshot are atomic per
+// element, not for the array as a whole.
+//
+// The zero value is an empty array.
+type Uint64Array struct {
+	_ nocmp // disallow non-atomic comparison
+
+	stride int
+	v      []Uint64
+}
+
+// NewUint64Array creates a Uint64Array of n zeroes.
+func NewUint64Array(n int, opts ...ArrayOption) *Uint64Array {
+	stride := arrayStride(n, opts)
+	return &Uint64Array{stride: stride, v: make([]Uint64, n*stride)}
+}
+
+func (a *Uint64Array) at(i int) *Uint64 {
+	return &a.v[i*a.stride]
+}
+
+// Len returns the number of elements in the array.
+func (a *Uint64Array) Len() int {
+	if a.stride == 0 {
+		return 0
+	}
+	return len(a.v) / a.stride
+}
+
+// Load atomically loads element i.
+func (a *Uint64Array) Load(i int) uint64 {
+	return a.at(i).Load()
+}
+
+// Store atomically stores val into element i.
+func (a *Uint64Array) Store(i int, val uint64) {
+	a.at(i).Store(val)
+}
+
+// Add atomically adds delta to element i and returns the new value.
+func (a *Uint64Array) Add(i int, delta uint64) uint64 {
+	return a.at(i).Add(delta)
+}
+
+// Swap atomically stores val into element i and returns the old value.
+func (a *Uint64Array) Swap(i int, val uint64) (old uint64) {
+	return a.at(i).Swap(val)
+}
+
+// CompareAndSwap is an atomic compare-and-swap for element i.
+func (a *Uint64Array) CompareAndSwap(i int, old, new uint64) (swapped bool) {
+	return a.at(i).CompareAndSwap(old, new)
+}
+
+// AddVector atomically adds deltas[i] to each element i. It panics if
+// deltas isn't the same length as the array.
+func (a *Uint64Array) AddVector(deltas []uint64) {
+	checkArrayLen("Uint64Array", a.Len(), len(deltas))
+	for i, d := range deltas {
+		if d != 0 {
+			a.at(i).Add(d)
+		}
+	}
+}
+
+// Snapshot loads every element into a new slice.
+func (a *Uint64Array) Snapshot() []uint64 {
+	s := make([]uint64, a.Len())
+	for i := range s {
+		s[i] = a.at(i).Load()
+	}
+	return s
+}
+
+// Reset stores zero into every element.
+func (a *Uint64Array) Reset() {
+	for i, n := 0, a.Len(); i < n; i++ {
+		a.at(i).Store(0)
+	}
+}
+
+// MarshalJSON encodes a snapshot of the array into a JSON array.
+func (a *Uint64Array) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Snapshot())
+}
+
+// UnmarshalJSON decodes a JSON array into the array, which must have the
+// same length. An empty array is instead resized to fit, keeping the
+// padding it was created with, which isn't safe to do concurrently with
+// other operations.
+func (a *Uint64Array) UnmarshalJSON(b []byte) error {
+	var v []uint64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if a.Len() == 0 && len(v) > 0 {
+		stride := a.stride
+		if stride == 0 {
+			stride = 1 // zero value
+		}
+		*a = Uint64Array{stride: stride, v: make([]Uint64, len(v)*stride)}
+	}
+	if len(v) != a.Len() {
+		return fmt.Errorf("atomic: cannot decode %d values into Uint64Array of length %d", len(v), a.Len())
+	}
+	for i, val := range v {
+		a.at(i).Store(val)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint64Array(t *testing.T) {
+	a := NewUint64Array(3)
+	require.Equal(t, 3, a.Len(), "Len didn't match.")
+
+	a.Store(0, 42)
+	assert.Equal(t, uint64(42), a.Load(0), "Store didn't set the correct value.")
+	assert.Equal(t, uint64(44), a.Add(0, 2), "Add didn't work.")
+	assert.Equal(t, uint64(44), a.Swap(0, 1), "Swap didn't return the old value.")
+	assert.True(t, a.CompareAndSwap(0, 1, 3), "CAS didn't report a swap.")
+
+	a.AddVector([]uint64{1, 2, 3})
+	assert.Equal(t, []uint64{4, 2, 3}, a.Snapshot(), "AddVector didn't add to every element.")
+
+	bytes, err := json.Marshal(a)
+	require.NoError(t, err, "json.Marshal errored unexpectedly.")
+	assert.Equal(t, "[4,2,3]", string(bytes), "json.Marshal encoded the wrong bytes.")
+	err = json.Unmarshal([]byte("[-1,0,0]"), a)
+	assertErrorJSONUnmarshalType(t, err,
+		"json.Unmarshal failed with unexpected error %v, want UnmarshalTypeError.", err)
+
+	a.Reset()
+	assert.Equal(t, []uint64{0, 0, 0}, a.Snapshot(), "Reset didn't zero every element.")
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// The views below work because each wrapper type holds nothing but the
+// wrapped value, at offset 0, and only ever accesses it atomically.
+
+// Int32At returns an Int32 that operates atomically on the int32 at p,
+// such as a field of a struct you don't own. p must not be nil and must be
+// 4-byte aligned, or Int32At panics.
+//
+// All access to *p must go through atomic operations while the view is in
+// use.
+func Int32At(p *int32) *Int32 {
+	checkView(unsafe.Pointer(p), 4, "int32")
+	return (*Int32)(unsafe.Pointer(p))
+}
+
+// Int64At returns an Int64 that operates atomically on the int64 at p,
+// such as an element of a []int64 shared through mmap or cgo. p must not
+// be nil and must be 8-byte aligned, even on 64-bit platforms, or Int64At
+// panics.
+//
+// All access to *p must go through atomic operations while the view is in
+// use.
+func Int64At(p *int64) *Int64 {
+	checkView(unsafe.Pointer(p), 8, "int64")
+	return (*Int64)(unsafe.Pointer(p))
+}
+
+// Uint32At returns a Uint32 that operates atomically on the uint32 at p.
+// p must not be nil and must be 4-byte aligned, or Uint32At panics.
+//
+// All access to *p must go through atomic operations while the view is in
+// use.
+func Uint32At(p *uint32) *Uint32 {
+	checkView(unsafe.Pointer(p), 4, "uint32")
+	return (*Uint32)(unsafe.Pointer(p))
+}
+
+// Uint64At returns a Uint64 that operates atomically on the uint64 at p.
+// p must not be nil and must be 8-byte aligned, even on 64-bit platforms,
+// or Uint64At panics.
+//
+// All access to *p must go through atomic operations while the view is in
+// use.
+func Uint64At(p *uint64) *Uint64 {
+	checkView(unsafe.Pointer(p), 8, "uint64")
+	return (*Uint64)(unsafe.Pointer(p))
+}
+
+// UintptrAt returns a Uintptr that operates atomically on the uintptr at
+// p. p must not be nil and must be aligned to the size of a uintptr, or
+// UintptrAt panics.
+//
+// All access to *p must go through atomic operations while the view is in
+// use.
+func UintptrAt(p *uintptr) *Uintptr {
+	checkView(unsafe.Pointer(p), unsafe.Sizeof(uintptr(0)), "uintptr")
+	return (*Uintptr)(unsafe.Pointer(p))
+}
+
+func checkView(p unsafe.Pointer, align uintptr, typ string) {
+	if p == nil {
+		panic(fmt.Sprintf("atomic: cannot view nil *%v", typ))
+	}
+	checkAlignment(uintptr(p), align, typ)
+}
+
+func checkAlignment(addr, align uintptr, typ string) {
+	if addr%align != 0 {
+		panic(fmt.Sprintf("atomic: %v at %#x is not %d-byte aligned", typ, addr, align))
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.19
+// +build go1.19
+
+package atomic
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// Int32FromStd returns an Int32 that operates on the same value as v.
+func Int32FromStd(v *atomic.Int32) *Int32 {
+	return (*Int32)(unsafe.Pointer(v))
+}
+
+// Std returns a sync/atomic Int32 that operates on the same value as i.
+func (i *Int32) Std() *atomic.Int32 {
+	return (*atomic.Int32)(unsafe.Pointer(i))
+}
+
+// Int64FromStd returns an Int64 that operates on the same value as v.
+func Int64FromStd(v *atomic.Int64) *Int64 {
+	return (*Int64)(unsafe.Pointer(v))
+}
+
+// Std returns a sync/atomic Int64 that operates on the same value as i.
+// It panics if i isn't 8-byte aligned, which sync/atomic.Int64 guarantees.
+func (i *Int64) Std() *atomic.Int64 {
+	checkAlignment(uintptr(unsafe.Pointer(i)), 8, "Int64")
+	return (*atomic.Int64)(unsafe.Pointer(i))
+}
+
+// Uint32FromStd returns a Uint32 that operates on the same value as v.
+func Uint32FromStd(v *atomic.Uint32) *Uint32 {
+	return (*Uint32)(unsafe.Pointer(v))
+}
+
+// Std returns a sync/atomic Uint32 that operates on the same value as i.
+func (i *Uint32) Std() *atomic.Uint32 {
+	return (*atomic.Uint32)(unsafe.Pointer(i))
+}
+
+// Uint64FromStd returns a Uint64 that operates on the same value as v.
+func Uint64FromStd(v *atomic.Uint64) *Uint64 {
+	return (*Uint64)(unsafe.Pointer(v))
+}
+
+// Std returns a sync/atomic Uint64 that operates on the same value as i.
+// It panics if i isn't 8-byte aligned, which sync/atomic.Uint64 guarantees.
+func (i *Uint64) Std() *atomic.Uint64 {
+	checkAlignment(uintptr(unsafe.Pointer(i)), 8, "Uint64")
+	return (*atomic.Uint64)(unsafe.Pointer(i))
+}
+
+// UintptrFromStd returns a Uintptr that operates on the same value as v.
+func UintptrFromStd(v *atomic.Uintptr) *Uintptr {
+	return (*Uintptr)(unsafe.Pointer(v))
+}
+
+// Std returns a sync/atomic Uintptr that operates on the same value as i.
+func (i *Uintptr) Std() *atomic.Uintptr {
+	return (*atomic.Uintptr)(unsafe.Pointer(i))
+}
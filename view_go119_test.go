@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.19
+// +build go1.19
+
+package atomic
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewsStd(t *testing.T) {
+	var (
+		i32 atomic.Int32
+		i64 atomic.Int64
+		u32 atomic.Uint32
+		u64 atomic.Uint64
+		ptr atomic.Uintptr
+	)
+
+	Int32FromStd(&i32).Store(1)
+	Int64FromStd(&i64).Store(2)
+	Uint32FromStd(&u32).Store(3)
+	Uint64FromStd(&u64).Store(4)
+	UintptrFromStd(&ptr).Store(5)
+
+	assert.Equal(t, int32(1), i32.Load(), "Int32FromStd didn't share the value.")
+	assert.Equal(t, int64(2), i64.Load(), "Int64FromStd didn't share the value.")
+	assert.Equal(t, uint32(3), u32.Load(), "Uint32FromStd didn't share the value.")
+	assert.Equal(t, uint64(4), u64.Load(), "Uint64FromStd didn't share the value.")
+	assert.Equal(t, uintptr(5), ptr.Load(), "UintptrFromStd didn't share the value.")
+
+	var (
+		a Int32
+		b Int64
+		c Uint32
+		d Uint64
+		e Uintptr
+	)
+	a.Std().Add(1)
+	b.Std().Add(2)
+	c.Std().Add(3)
+	d.Std().Add(4)
+	e.Std().Add(5)
+
+	assert.Equal(t, int32(1), a.Load(), "Std didn't share the value.")
+	assert.Equal(t, int64(2), b.Load(), "Std didn't share the value.")
+	assert.Equal(t, uint32(3), c.Load(), "Std didn't share the value.")
+	assert.Equal(t, uint64(4), d.Load(), "Std didn't share the value.")
+	assert.Equal(t, uintptr(5), e.Load(), "Std didn't share the value.")
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Views of 64-bit values need 8-byte alignment, which 32-bit platforms
+// only guarantee for the first word of global variables and heap
+// allocations, not for stack variables. Use globals so that the test
+// doesn't depend on where escape analysis places its values.
+var (
+	_viewFields viewFields
+	_viewWords  [4]uint64
+)
+
+type viewFields struct {
+	i64 int64 // 64-bit fields first
+	u64 uint64
+	i32 int32
+	u32 uint32
+	ptr uintptr
+}
+
+func TestViews(t *testing.T) {
+	s := &_viewFields
+	*s = viewFields{}
+
+	Int32At(&s.i32).Add(-3)
+	Uint32At(&s.u32).Store(4)
+	Int64At(&s.i64).Sub(5)
+	assert.True(t, Uint64At(&s.u64).CompareAndSwap(0, 6), "CAS through a view didn't report a swap.")
+	UintptrAt(&s.ptr).Inc()
+
+	assert.Equal(t, int32(-3), s.i32, "Int32At didn't update the underlying value.")
+	assert.Equal(t, uint32(4), s.u32, "Uint32At didn't update the underlying value.")
+	assert.Equal(t, int64(-5), s.i64, "Int64At didn't update the underlying value.")
+	assert.Equal(t, uint64(6), s.u64, "Uint64At didn't update the underlying value.")
+	assert.Equal(t, uintptr(1), s.ptr, "UintptrAt didn't update the underlying value.")
+
+	words := _viewWords[:]
+	for i := range words {
+		words[i] = 0
+	}
+	Uint64At(&words[2]).Add(10)
+	assert.Equal(t, []uint64{0, 0, 10, 0}, words, "Uint64At didn't update the slice element.")
+
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, "atomic: cannot view nil *int64", func() { Int64At(nil) })
+		assert.Panics(t, func() { Int32At(nil) })
+		assert.Panics(t, func() { Uint32At(nil) })
+		assert.Panics(t, func() { Uint64At(nil) })
+		assert.Panics(t, func() { UintptrAt(nil) })
+	})
+
+	t.Run("alignment", func(t *testing.T) {
+		// Misaligned pointers can't be built safely in Go (the race
+		// detector rejects them), so check the alignment test directly.
+		assert.NotPanics(t, func() { checkAlignment(0x1008, 8, "int64") })
+		assert.PanicsWithValue(t, "atomic: int64 at 0x1004 is not 8-byte aligned", func() {
+			checkAlignment(0x1004, 8, "int64")
+		})
+		assert.Panics(t, func() { checkAlignment(0x1002, 4, "int32") })
+	})
+
+	t.Run("layout", func(t *testing.T) {
+		// Views rely on each type holding only the value, at offset 0.
+		assert.Zero(t, unsafe.Offsetof(Int32{}.v))
+		assert.Zero(t, unsafe.Offsetof(Int64{}.v))
+		assert.Zero(t, unsafe.Offsetof(Uint32{}.v))
+		assert.Zero(t, unsafe.Offsetof(Uint64{}.v))
+		assert.Zero(t, unsafe.Offsetof(Uintptr{}.v))
+	})
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"fmt"
+	"time"
+)
+
+// WindowedCounter counts events over a sliding time window.
+//
+// The window is split into a fixed number of buckets, each covering an equal
+// slice of time. Buckets are reused as time moves forward, so memory stays
+// bounded regardless of how many events are recorded. Sum reports the events
+// recorded in the buckets that overlap the window ending now; its resolution
+// is therefore one bucket width.
+//
+// WindowedCounter must be created with NewWindowedCounter.
+type WindowedCounter struct {
+	_ nocmp // disallow non-atomic comparison
+
+	now     func() time.Time
+	width   int64 // bucket width in nanoseconds
+	buckets []Pointer[windowBucket]
+}
+
+// windowBucket holds the events recorded during a single bucket-wide slice
+// of time. A bucket is never reset in place: rotating a slot to a new slice
+// of time swaps in a fresh windowBucket. An Add that races with the rotation
+// either lands in the new bucket or in the replaced one, and events in the
+// replaced bucket have already left the window.
+type windowBucket struct {
+	epoch int64 // index of the time slice, in bucket widths since the Unix epoch
+	count Int64
+}
+
+// WindowedCounterOption customizes a WindowedCounter.
+type WindowedCounterOption interface {
+	applyWindowedCounter(*WindowedCounter)
+}
+
+type windowedCounterClock func() time.Time
+
+func (o windowedCounterClock) applyWindowedCounter(c *WindowedCounter) {
+	c.now = o
+}
+
+// WithWindowedCounterClock makes the WindowedCounter read the current time
+// from now instead of time.Now. This is primarily useful in tests.
+func WithWindowedCounterClock(now func() time.Time) WindowedCounterOption {
+	return windowedCounterClock(now)
+}
+
+// NewWindowedCounter creates a WindowedCounter that counts events over the
+// given window, split into the given number of buckets.
+//
+// NewWindowedCounter panics if window is not positive, if buckets is less
+// than one, or if window doesn't split evenly into buckets nanoseconds.
+func NewWindowedCounter(window time.Duration, buckets int, opts ...WindowedCounterOption) *WindowedCounter {
+	if window <= 0 {
+		panic(fmt.Sprintf("atomic: WindowedCounter window must be positive, got %v", window))
+	}
+	if buckets < 1 {
+		panic(fmt.Sprintf("atomic: WindowedCounter needs at least one bucket, got %d", buckets))
+	}
+	if int64(window) < int64(buckets) {
+		panic(fmt.Sprintf("atomic: WindowedCounter window %v is too short for %d buckets", window, buckets))
+	}
+	if int64(window)%int64(buckets) != 0 {
+		panic(fmt.Sprintf("atomic: WindowedCounter window %v doesn't split evenly into %d buckets", window, buckets))
+	}
+
+	c := &WindowedCounter{
+		now:     time.Now,
+		width:   int64(window) / int64(buckets),
+		buckets: make([]Pointer[windowBucket], buckets),
+	}
+	for _, opt := range opts {
+		opt.applyWindowedCounter(c)
+	}
+	return c
+}
+
+// Window reports the span of time covered by Sum.
+func (c *WindowedCounter) Window() time.Duration {
+	return time.Duration(c.width * int64(len(c.buckets)))
+}
+
+// Add atomically records delta events at the current time.
+func (c *WindowedCounter) Add(delta int64) {
+	epoch := c.epoch()
+	idx := epoch % int64(len(c.buckets))
+	if idx < 0 {
+		idx += int64(len(c.buckets))
+	}
+	slot := &c.buckets[idx]
+
+	var fresh *windowBucket
+	for {
+		b := slot.Load()
+		switch {
+		case b != nil && b.epoch == epoch:
+			b.count.Add(delta)
+			return
+		case b != nil && b.epoch > epoch:
+			// The clock went backwards far enough for this slot to have
+			// moved on to a newer slice of time. The event is older than
+			// the window, so there's nowhere to record it.
+			return
+		}
+
+		if fresh == nil {
+			fresh = &windowBucket{epoch: epoch}
+		}
+		if slot.CompareAndSwap(b, fresh) {
+			fresh.count.Add(delta)
+			return
+		}
+		// Another goroutine rotated the slot first. Retry against the
+		// bucket it installed.
+	}
+}
+
+// Inc atomically records a single event at the current time.
+func (c *WindowedCounter) Inc() {
+	c.Add(1)
+}
+
+// Sum reports the number of events recorded during the window ending at the
+// current time.
+func (c *WindowedCounter) Sum() int64 {
+	epoch := c.epoch()
+	oldest := epoch - int64(len(c.buckets))
+
+	var sum int64
+	for i := range c.buckets {
+		b := c.buckets[i].Load()
+		if b != nil && b.epoch > oldest && b.epoch <= epoch {
+			sum += b.count.Load()
+		}
+	}
+	return sum
+}
+
+func (c *WindowedCounter) epoch() int64 {
+	nanos := c.now().UnixNano()
+	epoch := nanos / c.width
+	if nanos < 0 && nanos%c.width != 0 {
+		// Round towards negative infinity so that each epoch covers
+		// exactly one bucket width, even before 1970.
+		epoch--
+	}
+	return epoch
+}
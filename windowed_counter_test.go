@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a clock for tests that only moves when told to.
+type fakeClock struct{ nanos Int64 }
+
+func newFakeClock() *fakeClock {
+	c := &fakeClock{}
+	c.nanos.Store(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano())
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	return time.Unix(0, c.nanos.Load())
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.nanos.Add(int64(d))
+}
+
+func TestWindowedCounter(t *testing.T) {
+	clock := newFakeClock()
+	c := NewWindowedCounter(10*time.Second, 10, WithWindowedCounterClock(clock.Now))
+	require.Equal(t, 10*time.Second, c.Window(), "Window didn't match.")
+	require.Equal(t, int64(0), c.Sum(), "Initial Sum should be zero.")
+
+	c.Inc()
+	c.Add(4)
+	require.Equal(t, int64(5), c.Sum(), "Sum didn't include recent events.")
+
+	clock.Advance(3 * time.Second)
+	c.Add(2)
+	require.Equal(t, int64(7), c.Sum(), "Sum didn't include events across buckets.")
+
+	clock.Advance(7 * time.Second)
+	require.Equal(t, int64(2), c.Sum(), "Sum didn't drop events older than the window.")
+
+	clock.Advance(3 * time.Second)
+	require.Equal(t, int64(0), c.Sum(), "Sum didn't drop all expired events.")
+
+	c.Add(3)
+	require.Equal(t, int64(3), c.Sum(), "Rotated bucket didn't start from zero.")
+
+	t.Run("clock moving backwards", func(t *testing.T) {
+		clock.Advance(-20 * time.Second)
+		c.Add(100)
+		clock.Advance(20 * time.Second)
+		assert.Equal(t, int64(3), c.Sum(), "Events older than the window should be dropped.")
+	})
+}
+
+func TestWindowedCounterRotationRace(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 1000
+	)
+
+	clock := newFakeClock()
+	c := NewWindowedCounter(time.Second, 4, WithWindowedCounterClock(clock.Now))
+
+	// Move the clock to just before a bucket boundary so that the
+	// goroutines below race across the rotation.
+	clock.Advance(250*time.Millisecond - time.Duration(clock.nanos.Load()%int64(250*time.Millisecond)) - time.Nanosecond)
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			for j := 0; j < iterations; j++ {
+				if i == 0 && j == iterations/2 {
+					clock.Advance(time.Nanosecond)
+				}
+				c.Inc()
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int64(goroutines*iterations), c.Sum(),
+		"Events were lost while the bucket rotated.")
+}
+
+func TestNewWindowedCounterPanics(t *testing.T) {
+	tests := []struct {
+		desc    string
+		window  time.Duration
+		buckets int
+	}{
+		{desc: "zero window", window: 0, buckets: 1},
+		{desc: "negative window", window: -time.Second, buckets: 1},
+		{desc: "no buckets", window: time.Second, buckets: 0},
+		{desc: "window too short", window: 2, buckets: 3},
+		{desc: "uneven split", window: 10, buckets: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Panics(t, func() {
+				NewWindowedCounter(tt.window, tt.buckets)
+			})
+		})
+	}
+}
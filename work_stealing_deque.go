@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import "fmt"
+
+const _defaultDequeCapacity = 32
+
+// WorkStealingDeque is a Chase-Lev work-stealing deque.
+//
+// One goroutine, the owner, pushes and pops values at the bottom of the
+// deque like a stack. Any number of other goroutines may concurrently
+// Steal values from the top. The deque grows as needed; it never shrinks.
+//
+// The algorithm relies on the owner's write of bottom in PopBottom being
+// ordered before its read of top, and on the thieves' read of top being
+// ordered before their read of bottom. Chase and Lev's original
+// formulation needs a full memory fence for the former; this package's
+// atomics are sequentially consistent, so the ordering holds without one.
+// Each value is stored in its slot behind an atomic pointer so that a thief
+// reading a slot the owner is overwriting isn't a data race: such a thief
+// always loses its compare-and-swap on top and discards what it read.
+//
+// The zero value is an empty deque ready to use.
+type WorkStealingDeque[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	_      cacheLinePad
+	top    Int64 // next index to steal; only ever incremented
+	_      cacheLinePad
+	bottom Int64 // next index to push; written only by the owner
+	_      cacheLinePad
+	array  Pointer[dequeArray[T]]
+}
+
+// dequeArray is a circular array indexed by the deque's ever-increasing
+// top and bottom positions.
+type dequeArray[T any] struct {
+	mask  int64
+	slots []Pointer[T]
+}
+
+func newDequeArray[T any](capacity int) *dequeArray[T] {
+	return &dequeArray[T]{
+		mask:  int64(capacity - 1),
+		slots: make([]Pointer[T], capacity),
+	}
+}
+
+func (a *dequeArray[T]) load(i int64) *T     { return a.slots[i&a.mask].Load() }
+func (a *dequeArray[T]) store(i int64, v *T) { a.slots[i&a.mask].Store(v) }
+
+// grow returns a copy of a with twice the capacity, holding the values
+// between top and bottom. Thieves still using the old array keep reading
+// valid values from it.
+func (a *dequeArray[T]) grow(top, bottom int64) *dequeArray[T] {
+	next := newDequeArray[T](2 * len(a.slots))
+	for i := top; i < bottom; i++ {
+		next.store(i, a.load(i))
+	}
+	return next
+}
+
+// NewWorkStealingDeque creates an empty WorkStealingDeque with room for
+// capacity values before it needs to grow. It panics if capacity isn't a
+// power of two.
+func NewWorkStealingDeque[T any](capacity int) *WorkStealingDeque[T] {
+	if capacity < 1 || capacity&(capacity-1) != 0 {
+		panic(fmt.Sprintf("atomic: deque capacity must be a power of two, got %d", capacity))
+	}
+	d := &WorkStealingDeque[T]{}
+	d.array.Store(newDequeArray[T](capacity))
+	return d
+}
+
+// PushBottom adds value to the bottom of the deque. Only the owner may call
+// it.
+func (d *WorkStealingDeque[T]) PushBottom(value T) {
+	b := d.bottom.Load()
+	t := d.top.Load()
+	a := d.array.Load()
+	switch {
+	case a == nil:
+		a = newDequeArray[T](_defaultDequeCapacity)
+		d.array.Store(a)
+	case b-t > a.mask:
+		a = a.grow(t, b)
+		d.array.Store(a)
+	}
+	a.store(b, &value)
+	// Publishes the value: thieves only read slots below bottom.
+	d.bottom.Store(b + 1)
+}
+
+// PopBottom removes and returns the most recently pushed value. It reports
+// false if the deque is empty. Only the owner may call it.
+func (d *WorkStealingDeque[T]) PopBottom() (value T, ok bool) {
+	b := d.bottom.Load() - 1
+	a := d.array.Load()
+	if a == nil {
+		return value, false
+	}
+	// Reserve index b before looking at top, so that a thief that reads top
+	// after this sees the smaller bottom and leaves b alone.
+	d.bottom.Store(b)
+	t := d.top.Load()
+	if t > b {
+		d.bottom.Store(b + 1)
+		return value, false
+	}
+
+	p := a.load(b)
+	if t == b {
+		// Last value: race thieves for it by claiming it from the top.
+		won := d.top.CompareAndSwap(t, t+1)
+		d.bottom.Store(b + 1)
+		if !won {
+			return value, false
+		}
+	}
+	a.store(b, nil)
+	return *p, true
+}
+
+// Steal removes and returns the least recently pushed value. It reports
+// false if the deque is empty. Any goroutine may call it.
+func (d *WorkStealingDeque[T]) Steal() (value T, ok bool) {
+	for {
+		t := d.top.Load()
+		b := d.bottom.Load()
+		if t >= b {
+			return value, false
+		}
+		p := d.array.Load().load(t)
+		if p == nil {
+			// The owner popped this value after we read top; try again.
+			continue
+		}
+		if d.top.CompareAndSwap(t, t+1) {
+			return *p, true
+		}
+		// Another thief or the owner took index t first.
+	}
+}
+
+// Len reports the number of values in the deque. Under concurrent use it's
+// only an approximation.
+func (d *WorkStealingDeque[T]) Len() int {
+	b := d.bottom.Load()
+	t := d.top.Load()
+	if n := b - t; n > 0 {
+		return int(n)
+	}
+	return 0
+}
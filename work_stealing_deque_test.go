@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package atomic
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkStealingDeque(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var d WorkStealingDeque[int]
+		_, ok := d.PopBottom()
+		assert.False(t, ok, "PopBottom of an empty deque should report false.")
+		_, ok = d.Steal()
+		assert.False(t, ok, "Steal of an empty deque should report false.")
+		d.PushBottom(1)
+		v, ok := d.Steal()
+		assert.True(t, ok && v == 1, "Steal didn't return the pushed value.")
+	})
+
+	d := NewWorkStealingDeque[int](2)
+	for i := 0; i < 10; i++ {
+		d.PushBottom(i) // grows the array a few times
+	}
+	assert.Equal(t, 10, d.Len(), "Len didn't count every push.")
+
+	v, ok := d.PopBottom()
+	require.True(t, ok, "PopBottom should succeed.")
+	assert.Equal(t, 9, v, "PopBottom should return the newest value.")
+
+	v, ok = d.Steal()
+	require.True(t, ok, "Steal should succeed.")
+	assert.Equal(t, 0, v, "Steal should return the oldest value.")
+
+	for i := 8; i >= 1; i-- {
+		v, ok := d.PopBottom()
+		require.True(t, ok, "PopBottom should succeed.")
+		assert.Equal(t, i, v, "PopBottom didn't return values in LIFO order.")
+	}
+	_, ok = d.PopBottom()
+	assert.False(t, ok, "PopBottom of an emptied deque should report false.")
+	_, ok = d.Steal()
+	assert.False(t, ok, "Steal of an emptied deque should report false.")
+	assert.Equal(t, 0, d.Len(), "Len of an emptied deque should be 0.")
+
+	t.Run("panics", func(t *testing.T) {
+		for _, n := range []int{-1, 0, 3} {
+			assert.Panics(t, func() { NewWorkStealingDeque[int](n) }, "Capacity %d should panic.", n)
+		}
+	})
+}
+
+// TestWorkStealingDequeStress checks the properties a linearizable deque
+// must have: every pushed value is taken exactly once, each thief sees
+// values in push order, and the owner never pops a value older than one it
+// popped before without an intervening push.
+func TestWorkStealingDequeStress(t *testing.T) {
+	const (
+		thieves = 4
+		n       = 50000
+	)
+
+	var (
+		d      = NewWorkStealingDeque[int](4)
+		done   Bool
+		stolen = make([][]int, thieves)
+		popped []int
+		wg     sync.WaitGroup
+	)
+
+	wg.Add(thieves)
+	for i := 0; i < thieves; i++ {
+		go func(i int) {
+			defer wg.Done()
+			for {
+				if v, ok := d.Steal(); ok {
+					stolen[i] = append(stolen[i], v)
+				} else if done.Load() {
+					return
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}(i)
+	}
+
+	for next := 0; next < n; {
+		// Push a burst, then pop part of it back, so that the owner and
+		// thieves regularly contend for the last value.
+		burst := next%7 + 1
+		for j := 0; j < burst && next < n; j++ {
+			d.PushBottom(next)
+			next++
+		}
+		last := next
+		for j := 0; j < burst/2; j++ {
+			v, ok := d.PopBottom()
+			if !ok {
+				break
+			}
+			require.True(t, v < last, "PopBottom returned %d, not older than %d.", v, last)
+			last = v
+			popped = append(popped, v)
+		}
+	}
+	for {
+		v, ok := d.PopBottom()
+		if !ok {
+			break
+		}
+		popped = append(popped, v)
+	}
+	done.Store(true)
+	wg.Wait()
+
+	for i, vs := range stolen {
+		for j := 1; j < len(vs); j++ {
+			require.True(t, vs[j-1] < vs[j], "Thief %d stole %d after %d.", i, vs[j], vs[j-1])
+		}
+	}
+	checkExactlyOnce(t, n, append(stolen, popped))
+}